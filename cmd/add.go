@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/openapi"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var addProjectDir string = "." // project to extend, defaults to current directory
+
+// addCmd represents the add command
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add generated code to an existing project",
+}
+
+// addOpenAPICmd represents the add openapi command
+var addOpenAPICmd = &cobra.Command{
+	Use:   "openapi [spec-file]",
+	Short: "Scaffold handlers, models and routes from an OpenAPI 3 document",
+	Long: `Scaffold handlers, models and routes from a local OpenAPI 3 document.
+Go web projects receive code under internal/handlers, internal/models and internal/routes,
+Express and Fastify projects receive a router under src/routes and models under src/models.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(addProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		doc, err := openapi.Load(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := scaffoldOpenAPI(doc, path); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// scaffoldOpenAPI generates code from the OpenAPI document into the project at dir
+// and reports any step that has to be completed by hand.
+func scaffoldOpenAPI(doc *openapi.Document, dir string) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	info, err := projects.DetectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := openapi.NewGenerator(doc, info)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Scaffolded %d operations from %s\n", green("✓"), len(doc.Endpoints()), doc.Info.Title)
+	for _, warning := range generator.Warnings {
+		fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.AddCommand(addOpenAPICmd)
+
+	// -d flag to specify the project directory
+	addOpenAPICmd.Flags().StringVarP(&addProjectDir, "dir", "d", ".", "project directory to add the generated code to")
+}
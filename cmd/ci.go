@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/ci"
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciProjectDir string = "."      // project to generate the pipeline for
+	ciProvider   string = "github" // github or gitlab
+	ciDocker     bool   = false    // add docker build jobs, detected from the Dockerfile when unset
+	ciK8s        bool   = false    // add a manifest validation job, detected from k8s/ when unset
+	ciBranch     string = ""       // branch running the pipeline, the checked out one when unset
+	ciForce      bool   = false    // overwrite an existing pipeline
+)
+
+// ciCmd represents the ci command
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Generate a CI pipeline for GitHub Actions or GitLab CI",
+	Long: `Generate a CI pipeline tailored to the project type.
+Go projects are vetted, tested and built, Node.js projects are installed with their
+package manager and run their lint, test and build scripts.
+
+A Docker build job is added when the project has a Dockerfile and a job validating
+the Kubernetes manifests with kubeconform when it has a k8s/ directory.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(ciProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		provider, err := ci.ParseProvider(ciProvider)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var withDocker, withK8s *bool
+		if cmd.Flags().Changed("docker") {
+			withDocker = &ciDocker
+		}
+		if cmd.Flags().Changed("k8s") {
+			withK8s = &ciK8s
+		}
+
+		branch := ciBranch
+		if branch == "" {
+			branch = utils.CurrentBranch(path)
+		}
+
+		if err := generatePipeline(path, provider, branch, withDocker, withK8s, ciForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generatePipeline writes the CI pipeline of the project at dir, run on pushes to
+// branch or to main when it is empty. The Docker and Kubernetes jobs are detected
+// from the project unless withDocker or withK8s is set.
+func generatePipeline(dir string, provider ci.Provider, branch string, withDocker *bool, withK8s *bool, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	var apps []ci.App
+	if projects.IsFullStack(dir) {
+		for _, part := range []string{"api", "web"} {
+			app, err := detectApp(dir, filepath.Join(dir, part), part, docker.DefaultImageName(dir)+"-"+part)
+			if err != nil {
+				return err
+			}
+			apps = append(apps, app)
+		}
+	} else {
+		app, err := detectApp(dir, dir, "build", "")
+		if err != nil {
+			return err
+		}
+		apps = append(apps, app)
+	}
+
+	if withDocker != nil {
+		for i := range apps {
+			apps[i].Docker = *withDocker
+		}
+	}
+
+	k8s := false
+	if withK8s != nil {
+		k8s = *withK8s
+	} else if stat, err := os.Stat(filepath.Join(dir, ci.ManifestsDir)); err == nil && stat.IsDir() {
+		k8s = true
+	}
+
+	generator := ci.NewGenerator(dir, provider, apps, k8s, force)
+	if branch != "" {
+		generator.Branch = branch
+	}
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s pipeline generated at: %s\n", green("✓"), provider, filepath.Join(dir, generator.Path()))
+	return nil
+}
+
+// detectApp describes the project at dir as an app of the repository at root
+func detectApp(root string, dir string, job string, image string) (ci.App, error) {
+	info, err := projects.DetectProject(dir)
+	if err != nil {
+		return ci.App{}, err
+	}
+	return ci.NewApp(root, info, job, image)
+}
+
+func init() {
+	rootCmd.AddCommand(ciCmd)
+
+	ciCmd.Flags().StringVarP(&ciProjectDir, "dir", "d", ".", "project directory to generate the pipeline in")
+	ciCmd.Flags().StringVarP(&ciProvider, "provider", "p", "github", "CI provider (github or gitlab)")
+	ciCmd.Flags().BoolVar(&ciDocker, "docker", false, "add jobs building the Docker images (default: when a Dockerfile exists)")
+	ciCmd.Flags().BoolVar(&ciK8s, "k8s", false, "add a job validating the Kubernetes manifests (default: when k8s/ exists)")
+	ciCmd.Flags().StringVar(&ciBranch, "branch", "", "branch whose pushes run the pipeline (default: the checked out branch, or main)")
+	ciCmd.Flags().BoolVarP(&ciForce, "force", "f", false, "overwrite an existing pipeline")
+}
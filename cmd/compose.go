@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/compose"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/moabdelazem/initiator/pkg"
+	"github.com/spf13/cobra"
+)
+
+var (
+	composeProjectDir string   = "."   // project to generate compose.yaml for
+	composeServices   []string = nil   // backing services, prompted for when empty
+	composeForce      bool     = false // overwrite an existing compose.yaml
+)
+
+// composeCmd represents the compose command
+var composeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Generate a compose.yaml for local development",
+	Long: `Generate a compose.yaml running the project, built from its Dockerfile, next to
+backing services. The connection variables of the services are added to .env.
+
+Supported services: ` + strings.Join(compose.ServiceNames(), ", ") + `
+
+Example:
+  initiator compose --services postgres,redis`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(composeProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var services []compose.Service
+		if cmd.Flags().Changed("services") {
+			if services, err = compose.ParseServices(composeServices); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			services = compose.PromptServices()
+		}
+
+		if err := generateCompose(path, services, composeForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generateCompose writes compose.yaml and the service variables of the project at dir
+func generateCompose(dir string, services []compose.Service, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	if projects.IsFullStack(dir) {
+		return fmt.Errorf("full-stack projects already have a compose.yaml, add the services to it by hand")
+	}
+
+	info, err := projects.DetectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := compose.NewGenerator(info, services, force)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s compose.yaml generated at: %s\n", green("✓"), dir)
+	if len(generator.AddedEnv) > 0 {
+		fmt.Printf("%s Added to .env: %s\n", green("✓"), strings.Join(generator.AddedEnv, ", "))
+	}
+	for _, warning := range generator.Warnings {
+		fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+	}
+
+	if pkg.IsDockerComposeInstalled() {
+		fmt.Printf("\nStart the stack with: %s\n", cyan("docker compose up --build"))
+	} else {
+		fmt.Printf("%s Docker Compose is not installed, see https://docs.docker.com/compose/install/\n", yellow("!"))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(composeCmd)
+
+	composeCmd.Flags().StringVarP(&composeProjectDir, "dir", "d", ".", "project directory to generate compose.yaml in")
+	composeCmd.Flags().StringSliceVarP(&composeServices, "services", "s", nil, "backing services to add ("+strings.Join(compose.ServiceNames(), ", ")+")")
+	composeCmd.Flags().BoolVarP(&composeForce, "force", "f", false, "overwrite an existing compose.yaml")
+}
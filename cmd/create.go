@@ -3,14 +3,42 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/ci"
+	"github.com/moabdelazem/initiator/internal/compose"
+	"github.com/moabdelazem/initiator/internal/gitignore"
+	"github.com/moabdelazem/initiator/internal/governance"
+	"github.com/moabdelazem/initiator/internal/license"
+	"github.com/moabdelazem/initiator/internal/openapi"
 	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/quality"
+	"github.com/moabdelazem/initiator/internal/tasks"
 	"github.com/moabdelazem/initiator/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-var targetDir string = "." // if not provided, default to current directory
-var initGit bool = true    // create git repository by default
+var targetDir string = "."      // if not provided, default to current directory
+var initGit bool = true         // create git repository by default
+var openapiSpec string          // optional OpenAPI document to scaffold from
+var withDocker bool             // generate a Dockerfile for the new project
+var composeWith []string        // backing services of the generated compose.yaml
+var ciProviderName string       // CI provider to generate a pipeline for
+var taskFileFormat string       // task runner to generate a task file for
+var qualityHookManager string   // hook manager of the quality tooling, empty to skip it
+var withGovernance bool         // generate CODEOWNERS, templates and the dependency bot config
+var codeOwners []string         // code owners of the governance files
+var dependencyBot string        // dependency update bot of the governance files
+var withDevContainer bool       // generate the dev container and VS Code configuration
+var licenseID string            // license to add to the new project
+var licenseHolderName string    // copyright holder of the license
+var gitOptions utils.GitOptions // default branch, remote, author and commit-msg hook
+var initialCommit bool          // commit the scaffold once every step succeeded
+
+// initialCommitMessage follows Conventional Commits so it passes the commit-msg hook
+const initialCommitMessage = "chore: initial commit from initiator"
 
 // createCmd represents the create command
 var createCmd = &cobra.Command{
@@ -19,6 +47,7 @@ var createCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectName := args[0]
+		yellow := color.New(color.FgYellow).SprintFunc()
 
 		// Validate the project name before proceeding
 		if err := utils.ValidateProjectName(projectName); err != nil {
@@ -26,6 +55,88 @@ var createCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// Resolve the compose services up front so a typo fails before anything is created
+		var services []compose.Service
+		if cmd.Flags().Changed("compose") {
+			var err error
+			if services, err = compose.ParseServices(composeWith); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the CI provider up front
+		var provider ci.Provider
+		if ciProviderName != "" {
+			var err error
+			if provider, err = ci.ParseProvider(ciProviderName); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the license up front
+		var projectLicense license.License
+		if licenseID != "" {
+			var err error
+			if projectLicense, err = license.Parse(licenseID); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the commit author up front
+		if gitOptions.Author != "" {
+			if _, _, err := utils.ParseAuthor(gitOptions.Author); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the hook manager of the quality tooling up front
+		var hooks quality.HookManager
+		if qualityHookManager != "" {
+			var err error
+			if hooks, err = quality.ParseHookManager(qualityHookManager); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the governance options up front, on the platform of the CI pipeline
+		var governanceOpts governanceOptions
+		if withGovernance {
+			platform := string(ci.GitHub)
+			if ciProviderName != "" {
+				platform = ciProviderName
+			}
+			var err error
+			if governanceOpts, err = parseGovernanceOptions(platform, dependencyBot, codeOwners, ""); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Validate the task file format up front
+		var format tasks.Format
+		if taskFileFormat != "" {
+			var err error
+			if format, err = tasks.ParseFormat(taskFileFormat); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Load the OpenAPI document up front so a broken spec fails before anything is created
+		var doc *openapi.Document
+		if openapiSpec != "" {
+			var err error
+			if doc, err = openapi.Load(openapiSpec); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Get The Target Directory And Get The absolute path
 		path, err := utils.GetAbsPath(targetDir, projectName)
 		if err != nil {
@@ -39,6 +150,14 @@ var createCmd = &cobra.Command{
 			return
 		}
 
+		// Configure the repository before anything is committed to it
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			if err := configureGit(path, gitOptions); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
 		// Print Success Message
 		fmt.Printf("Project '%s' created successfully at: %s\n", projectName, path)
 
@@ -53,9 +172,137 @@ var createCmd = &cobra.Command{
 			fmt.Printf("Error: %v\n", err)
 			return
 		}
+
+		// Scaffold handlers, models and routes from the OpenAPI document
+		if doc != nil {
+			if err := scaffoldOpenAPI(doc, path); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Add the LICENSE and reference it from the generated project files
+		if licenseID != "" {
+			if err := writeLicense(path, projectLicense, licenseHolderName, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate the Dockerfile once the project type and variant are known
+		if withDocker {
+			if err := generateDockerfile(path, "", false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate compose.yaml with the selected backing services. Full-stack projects
+		// already have a root compose.yaml, so the rest of the scaffold goes on without it.
+		if cmd.Flags().Changed("compose") {
+			if projects.IsFullStack(path) {
+				fmt.Printf("%s Skipped compose.yaml: full-stack projects already have one, add the services to it by hand\n", yellow("!"))
+			} else if err := generateCompose(path, services, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Add the quality tooling before the task file so its lint target runs golangci-lint
+		if hooks != "" {
+			if err := generateQualityTooling(path, hooks, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate the task file with targets for the artifacts generated above. Full-stack
+		// projects already have a root Makefile running the API and the frontend.
+		if format != "" {
+			if projects.IsFullStack(path) {
+				fmt.Printf("%s Skipped the task file: full-stack projects already have a Makefile running the API and the frontend\n", yellow("!"))
+			} else if err := generateTaskFile(path, format, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate the dev container after compose.yaml so Docker-in-Docker is added for it
+		if withDevContainer {
+			if err := generateDevContainer(path, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate the CI pipeline last so it picks up the Dockerfile
+		if provider != "" {
+			if err := generatePipeline(path, provider, gitOptions.Branch, nil, nil, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Generate the governance files once the hooks and the pipeline are known
+		if withGovernance {
+			if err := generateGovernance(path, governanceOpts, false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		// Compose .gitignore now that the project type and the generated files are known
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			if err := writeGitignore(path, gitignore.ForDir(path)); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+
+			// Record the scaffold as the first commit once every step succeeded
+			if initialCommit {
+				if err := commitScaffold(path, gitOptions); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					return
+				}
+			}
+		}
 	},
 }
 
+// configureGit applies the git options to the repository of a new project
+func configureGit(dir string, opts utils.GitOptions) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	if err := utils.ConfigureGitRepo(dir, opts); err != nil {
+		return err
+	}
+	if opts.Branch != "" {
+		fmt.Printf("%s Default branch set to %s\n", green("✓"), opts.Branch)
+	}
+	if opts.Remote != "" {
+		fmt.Printf("%s Added origin remote %s\n", green("✓"), opts.Remote)
+	}
+	if opts.CommitMsgHook {
+		fmt.Printf("%s Installed commit-msg hook enforcing Conventional Commits\n", green("✓"))
+	}
+	return nil
+}
+
+// commitScaffold records every generated file as the initial commit
+func commitScaffold(dir string, opts utils.GitOptions) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	s := utils.CreateSpinner("Creating initial commit...")
+	s.Start()
+	err := utils.CommitAll(dir, initialCommitMessage, opts)
+	s.Stop()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Created initial commit %q\n", green("✓"), initialCommitMessage)
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
 
@@ -63,4 +310,39 @@ func init() {
 	createCmd.Flags().StringVarP(&targetDir, "dir", "d", ".", "parent directory for the project")
 	// -ng flag to disable git init
 	createCmd.Flags().BoolVarP(&initGit, "no-git", "", true, "do not initialize a git repository")
+	// --openapi flag to scaffold the project from an OpenAPI document
+	createCmd.Flags().StringVar(&openapiSpec, "openapi", "", "OpenAPI 3 document to generate handlers, models and routes from")
+	// --docker flag to generate a Dockerfile and .dockerignore
+	createCmd.Flags().BoolVar(&withDocker, "docker", false, "generate a multi-stage Dockerfile and .dockerignore")
+	// --compose flag to generate compose.yaml with backing services
+	createCmd.Flags().StringSliceVar(&composeWith, "compose", nil, "generate compose.yaml with backing services ("+strings.Join(compose.ServiceNames(), ", ")+")")
+	// --tasks flag to generate a Makefile or Taskfile.yml
+	createCmd.Flags().StringVar(&taskFileFormat, "tasks", "", "generate a task file (make or task)")
+	// --ci flag to generate a CI pipeline
+	createCmd.Flags().StringVar(&ciProviderName, "ci", "", "generate a CI pipeline (github or gitlab)")
+	// --quality flag to add linter, formatter and Git hook configuration
+	createCmd.Flags().StringVar(&qualityHookManager, "quality", "", "add linter, formatter and Git hook configuration (pre-commit or lefthook)")
+	createCmd.Flags().Lookup("quality").NoOptDefVal = string(quality.PreCommit)
+	// --governance flag to add CODEOWNERS, issue and PR templates and the dependency bot config
+	createCmd.Flags().BoolVar(&withGovernance, "governance", false, "add CODEOWNERS, issue and PR templates, CONTRIBUTING.md, SECURITY.md and a dependency bot config")
+	// --owners flag to set the code owners
+	createCmd.Flags().StringSliceVar(&codeOwners, "owners", nil, "code owners like @org/team (defaults to $"+codeownersEnv+")")
+	// --bot flag to choose the dependency update bot
+	createCmd.Flags().StringVar(&dependencyBot, "bot", string(governance.Dependabot), "dependency update bot (dependabot or renovate)")
+	// --devcontainer flag to generate the dev container and editor configuration
+	createCmd.Flags().BoolVar(&withDevContainer, "devcontainer", false, "generate .devcontainer and .vscode configuration")
+	// --license flag to add a LICENSE file
+	createCmd.Flags().StringVar(&licenseID, "license", "", "add a LICENSE file ("+strings.Join(license.IDs(), ", ")+")")
+	// --license-holder flag to name the copyright holder
+	createCmd.Flags().StringVar(&licenseHolderName, "license-holder", "", "copyright holder of the license (defaults to git user.name)")
+	// --branch flag to name the default branch
+	createCmd.Flags().StringVar(&gitOptions.Branch, "branch", "main", "name of the default git branch")
+	// --remote flag to add the origin remote
+	createCmd.Flags().StringVar(&gitOptions.Remote, "remote", "", "URL of the origin git remote")
+	// --commit flag to record the scaffold as the initial commit
+	createCmd.Flags().BoolVar(&initialCommit, "commit", false, "commit the generated project once every step succeeded")
+	// --author flag to override the commit author
+	createCmd.Flags().StringVar(&gitOptions.Author, "author", "", "author of the initial commit as \"Name <email>\" (defaults to git config)")
+	// --conventional-commits flag to install the commit-msg hook
+	createCmd.Flags().BoolVar(&gitOptions.CommitMsgHook, "conventional-commits", false, "install a commit-msg hook enforcing Conventional Commits")
 }
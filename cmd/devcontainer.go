@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/devcontainer"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devcontainerProjectDir string = "."   // project to generate the editor configuration for
+	devcontainerForce      bool   = false // overwrite existing configuration files
+)
+
+// devcontainerCmd represents the devcontainer command
+var devcontainerCmd = &cobra.Command{
+	Use:   "devcontainer",
+	Short: "Generate a dev container and VS Code configuration for a project",
+	Long: `Generate .devcontainer/devcontainer.json with a base image matching the project
+(the Go version from go.mod, the installed Node.js version) and Docker-in-Docker when
+the project has a compose.yaml, plus .vscode/launch.json, tasks.json and the
+recommended extensions so the project builds and debugs out of the box.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(devcontainerProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if err := generateDevContainer(path, devcontainerForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generateDevContainer writes the dev container and VS Code configuration of the
+// project at dir. Full-stack projects get one configuration covering api/ and web/.
+func generateDevContainer(dir string, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	apps, err := projects.DetectApps(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := devcontainer.NewGenerator(dir, apps, force)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s .devcontainer/devcontainer.json generated at: %s\n", green("✓"), dir)
+	fmt.Printf("%s .vscode/launch.json, tasks.json and extensions.json generated\n", green("✓"))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(devcontainerCmd)
+
+	devcontainerCmd.Flags().StringVarP(&devcontainerProjectDir, "dir", "d", ".", "project directory to generate the configuration in")
+	devcontainerCmd.Flags().BoolVarP(&devcontainerForce, "force", "f", false, "overwrite existing configuration files")
+}
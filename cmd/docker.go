@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dockerProjectDir string = "."   // project to containerize, defaults to current directory
+	dockerImageName  string = ""    // image name, defaults to the project directory name
+	dockerForce      bool   = false // overwrite an existing Dockerfile
+)
+
+// dockerCmd represents the docker command
+var dockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "Generate a Dockerfile and .dockerignore for a project",
+	Long: `Generate a multi-stage Dockerfile and .dockerignore tailored to the project.
+Go projects are built into a distroless static image, Node.js projects get separate
+build and runtime stages, Next.js projects use the standalone output and Vite
+projects are served by nginx.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(dockerProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if err := generateDockerfile(path, dockerImageName, dockerForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generateDockerfile writes the Dockerfile of the project at dir. Full-stack projects
+// receive one Dockerfile for the API and one for the frontend.
+func generateDockerfile(dir string, imageName string, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	if projects.IsFullStack(dir) {
+		if imageName == "" {
+			imageName = docker.DefaultImageName(dir)
+		}
+		for _, part := range []string{"api", "web"} {
+			if err := generateDockerfile(filepath.Join(dir, part), imageName+"-"+part, force); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	info, err := projects.DetectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := docker.NewGenerator(info, imageName, force)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Dockerfile for image '%s' generated at: %s\n", green("✓"), generator.ImageName, dir)
+	for _, warning := range generator.Warnings {
+		fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(dockerCmd)
+
+	dockerCmd.Flags().StringVarP(&dockerProjectDir, "dir", "d", ".", "project directory to generate the Dockerfile in")
+	dockerCmd.Flags().StringVarP(&dockerImageName, "image", "i", "", "image name (defaults to the project directory name)")
+	dockerCmd.Flags().BoolVarP(&dockerForce, "force", "f", false, "overwrite an existing Dockerfile and .dockerignore")
+}
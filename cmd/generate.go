@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/resource"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourceFields     string = ""  // comma separated name:type pairs
+	generateProjectDir string = "." // project to generate into, defaults to current directory
+)
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:     "generate",
+	Aliases: []string{"g"},
+	Short:   "Generate code inside an existing project",
+}
+
+// generateResourceCmd represents the generate resource command
+var generateResourceCmd = &cobra.Command{
+	Use:   "resource [name]",
+	Short: "Generate a CRUD resource with model, service, handlers, routes and tests",
+	Long: `Generate a CRUD resource inside a project created by initiator.
+
+Example:
+  initiator generate resource user --fields name:string,age:int
+
+Supported field types: string, int, int64, float, bool, time.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		green := color.New(color.FgGreen).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+
+		name := args[0]
+
+		// Validate the resource name before proceeding
+		if err := utils.ValidateProjectName(name); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fields, err := resource.ParseFields(resourceFields)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		path, err := utils.GetAbsPath(generateProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		info, err := projects.DetectProject(path)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// The Go templates use the camelCase name as a variable
+		if info.Type == projects.GoLang && token.IsKeyword(utils.CamelCase(name)) {
+			fmt.Printf("Error: %q is a Go keyword, pick another resource name\n", name)
+			os.Exit(1)
+		}
+
+		generator := resource.NewGenerator(name, fields, info)
+		if err := generator.Generate(); err != nil {
+			fmt.Printf("Error generating resource: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, file := range generator.Files() {
+			fmt.Printf("%s Created %s\n", green("✓"), file)
+		}
+		for _, warning := range generator.Warnings {
+			fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+		}
+		fmt.Printf("\n%s Resource '%s' generated successfully\n", green("✨"), name)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.AddCommand(generateResourceCmd)
+
+	generateResourceCmd.Flags().StringVarP(&resourceFields, "fields", "f", "", "resource fields as name:type pairs, e.g. name:string,age:int")
+	generateResourceCmd.Flags().StringVarP(&generateProjectDir, "dir", "d", ".", "project directory to generate the resource in")
+	generateResourceCmd.MarkFlagRequired("fields")
+}
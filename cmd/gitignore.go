@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/gitignore"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var gitignoreProjectDir string = "." // project whose .gitignore is extended
+
+// gitignoreCmd represents the gitignore command
+var gitignoreCmd = &cobra.Command{
+	Use:   "gitignore",
+	Short: "Manage .gitignore fragments",
+}
+
+// gitignoreAddCmd represents the gitignore add command
+var gitignoreAddCmd = &cobra.Command{
+	Use:   "add [fragment...]",
+	Short: "Add language and tool fragments to .gitignore",
+	Long: `Add language and tool fragments to .gitignore without duplicating entries.
+Without arguments the fragments are chosen from the files in the project.
+
+Available fragments: ` + strings.Join(gitignore.Names(), ", "),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(gitignoreProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		names := args
+		if len(names) == 0 {
+			names = gitignore.ForDir(path)
+		}
+
+		if err := writeGitignore(path, names); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// gitignoreListCmd represents the gitignore list command
+var gitignoreListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available .gitignore fragments",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cyan := color.New(color.FgCyan).SprintFunc()
+
+		for _, name := range gitignore.Names() {
+			fragment, _ := gitignore.Get(name)
+			fmt.Printf("%s %s\n", cyan(fmt.Sprintf("%-10s", name)), fragment.Description)
+		}
+	},
+}
+
+// writeGitignore adds the fragments to the .gitignore of the project at dir
+func writeGitignore(dir string, names []string) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	added, err := gitignore.Add(filepath.Join(dir, ".gitignore"), names)
+	if err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		fmt.Printf("%s .gitignore already covers: %s\n", green("✓"), strings.Join(names, ", "))
+		return nil
+	}
+	fmt.Printf("%s Added %d entries to .gitignore (%s)\n", green("✓"), len(added), strings.Join(names, ", "))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(gitignoreCmd)
+	gitignoreCmd.AddCommand(gitignoreAddCmd)
+	gitignoreCmd.AddCommand(gitignoreListCmd)
+
+	gitignoreAddCmd.Flags().StringVarP(&gitignoreProjectDir, "dir", "d", ".", "project directory containing the .gitignore")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/ci"
+	"github.com/moabdelazem/initiator/internal/governance"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// codeownersEnv holds the default code owners, e.g. "@acme/platform"
+const codeownersEnv = "INITIATOR_CODEOWNERS"
+
+var (
+	governanceProjectDir string   = "."                           // repository to add the files to
+	governanceProvider   string   = string(ci.GitHub)             // hosting platform of the repository
+	governanceBot        string   = string(governance.Dependabot) // dependency update bot
+	governanceOwners     []string = nil                           // code owners, defaults to $INITIATOR_CODEOWNERS
+	governanceSecurity   string   = ""                            // where vulnerabilities are reported
+	governanceForce      bool     = false                         // overwrite existing files
+)
+
+// governanceCmd represents the governance command
+var governanceCmd = &cobra.Command{
+	Use:   "governance",
+	Short: "Add CODEOWNERS, issue and PR templates, contribution and security guides",
+	Long: `Add the repository governance files: CODEOWNERS, bug and feature issue templates,
+a pull (or merge) request template, CONTRIBUTING.md with the project's setup and
+check commands, SECURITY.md and a Dependabot or Renovate configuration watching the
+project's ecosystems.
+
+Code owners default to the ` + codeownersEnv + ` environment variable.
+
+Example:
+  initiator governance --owners @acme/platform --bot renovate`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(governanceProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		options, err := parseGovernanceOptions(governanceProvider, governanceBot, governanceOwners, governanceSecurity)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := generateGovernance(path, options, governanceForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// governanceOptions are the validated flags of the governance files
+type governanceOptions struct {
+	Provider        ci.Provider
+	Bot             governance.Bot
+	Owners          []string
+	SecurityContact string
+}
+
+// parseGovernanceOptions validates the governance flags. Owners fall back to the
+// comma separated handles of $INITIATOR_CODEOWNERS.
+func parseGovernanceOptions(provider string, bot string, owners []string, security string) (governanceOptions, error) {
+	var options governanceOptions
+	var err error
+
+	if options.Provider, err = ci.ParseProvider(provider); err != nil {
+		return options, err
+	}
+	if options.Bot, err = governance.ParseBot(bot); err != nil {
+		return options, err
+	}
+	if options.Provider == ci.GitLab && options.Bot == governance.Dependabot {
+		return options, fmt.Errorf("%s is only available on GitHub, use --bot %s", governance.Dependabot, governance.Renovate)
+	}
+
+	if len(owners) == 0 {
+		for _, owner := range strings.Split(os.Getenv(codeownersEnv), ",") {
+			if owner = strings.TrimSpace(owner); owner != "" {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	for _, owner := range owners {
+		if err := governance.ValidateOwner(owner); err != nil {
+			return options, err
+		}
+	}
+	options.Owners = owners
+	options.SecurityContact = security
+	return options, nil
+}
+
+// generateGovernance writes the governance files of the repository at dir
+func generateGovernance(dir string, options governanceOptions, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	apps, err := projects.DetectApps(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := governance.NewGenerator(dir, apps, options.Provider, options.Bot, options.Owners, force)
+	generator.SecurityContact = options.SecurityContact
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Governance files and %s configuration generated at: %s\n", green("✓"), options.Bot, dir)
+	for _, warning := range generator.Warnings {
+		fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(governanceCmd)
+
+	governanceCmd.Flags().StringVarP(&governanceProjectDir, "dir", "d", ".", "repository to add the governance files to")
+	governanceCmd.Flags().StringVarP(&governanceProvider, "provider", "p", string(ci.GitHub), "hosting platform (github or gitlab)")
+	governanceCmd.Flags().StringVar(&governanceBot, "bot", string(governance.Dependabot), "dependency update bot (dependabot or renovate)")
+	governanceCmd.Flags().StringSliceVar(&governanceOwners, "owners", nil, "code owners like @org/team (defaults to $"+codeownersEnv+")")
+	governanceCmd.Flags().StringVar(&governanceSecurity, "security-contact", "", "email address vulnerabilities are reported to")
+	governanceCmd.Flags().BoolVarP(&governanceForce, "force", "f", false, "overwrite existing files")
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/moabdelazem/initiator/internal/k8s"
+	"github.com/moabdelazem/initiator/internal/projects"
 	"github.com/moabdelazem/initiator/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -17,17 +19,153 @@ var (
 	outputDir     string = "."
 	containerName string = "" // New variable for container name
 	projectName   string = "" // New variable for project name
+	kustomize     bool   = false
+	environments  []string
+	helm          bool = false
+
+	imageRegistry   string = "" // defaults to $INITIATOR_REGISTRY
+	imageRepository string = "" // defaults to the container name
+	imageTag        string = "latest"
+	imageDigest     string = ""
+	replicas        int    = 1
+	pullPolicy      string = ""
+	size            string = k8s.DefaultSize
+	cpuRequest      string = ""
+	cpuLimit        string = ""
+	memoryRequest   string = ""
+	memoryLimit     string = ""
+
+	probeCheck            string = "" // defaults to the health endpoint of the project
+	livenessProbe         string = ""
+	readinessProbe        string = ""
+	startupProbe          string = ""
+	probeInitialDelay     int    = 0
+	probePeriod           int    = 0
+	probeTimeout          int    = 0
+	probeFailureThreshold int    = 0
+
+	envFile        string   = ""
+	secretPatterns []string = k8s.DefaultSecretPatterns
+	secretMode     string   = string(k8s.PlainSecret)
+
+	hpa               bool   = false
+	minReplicas       int    = 0 // defaults to --replicas
+	maxReplicas       int    = 5
+	cpuUtilization    int    = 80
+	memoryUtilization int    = 0
+	pdb               bool   = false
+	minAvailable      string = ""
+	maxUnavailable    string = "" // defaults to 1 when --min-available is not set
+
+	hosts              []string // defaults to <app-name>.example.com
+	ingressPath        string   = "/"
+	ingressClass       string   = ""
+	ingressAnnotations map[string]string
+	tlsSecret          string = ""
+	certIssuer         string = ""
+	gateway            string = ""
+	gatewaySection     string = ""
+
+	kind              string = string(k8s.Deployment)
+	schedule          string = ""
+	concurrencyPolicy string = "Forbid"
+	storageSize       string = "1Gi"
+	mountPath         string = "/data"
+	storageClass      string = ""
+
+	noHardening      bool   = false
+	runAsUser        int    = 0 // defaults to the user of the project's image
+	networkPolicy    bool   = false
+	ingressNamespace string = k8s.DefaultIngressNamespace
 )
 
+// registryEnv holds the default image registry of the k8s command
+const registryEnv = "INITIATOR_REGISTRY"
+
 // k8sCmd represents the k8s command
 var k8sCmd = &cobra.Command{
 	Use:   "k8s [app-name]",
 	Short: "Generate Kubernetes manifests",
 	Long: `Generate Kubernetes manifests for your application.
-Optionally include service and ingress resources.`,
-	Args: cobra.ExactArgs(1),
+Optionally include service and ingress resources.
+
+When the output directory holds a Go or Node.js project, the app name defaults to
+its module or package name, the port to the PORT of its .env or the port of its
+template (8080 for Go web projects, 3000 for Express), and the image to the title
+label of its Dockerfile. Flags override the inferred settings.
+
+With --kustomize the manifests are written into k8s/base with a kustomization.yaml,
+and an overlay per environment (dev, staging and prod by default) is written into
+k8s/overlays/<env>, patching the replicas, resources, image tag and ingress host.
+Apply one with: kubectl apply -k k8s/overlays/dev
+
+The image is built from --registry (default: $INITIATOR_REGISTRY), --image and --tag,
+or pinned with --digest. Resources come from the --size preset (small, medium or
+large) and can be overridden one by one with --cpu-request, --cpu-limit,
+--memory-request and --memory-limit. Quantities are validated before writing.
+
+Liveness, readiness and startup probes default to the health endpoint of the project
+in the output directory: /healthz for Go web, Express and Fastify projects created by
+initiator, / for the other web frameworks. --probe sets all three, --liveness,
+--readiness and --startup set one, each as http:/path, tcp[:port],
+grpc[:port[:service]], exec:<command> or none.
+
+--env-file loads a .env file into a ConfigMap and a Secret wired into the container
+with envFrom. Variables matching --secret-patterns, or referencing one, go into the
+Secret, written as a plain Secret, a SealedSecret or an ExternalSecret with --secrets.
+
+--hpa adds an autoscaling/v2 HorizontalPodAutoscaler that takes over the replica
+count, scaling between --min-replicas and --max-replicas on --cpu-utilization and
+--memory-utilization. --pdb adds a PodDisruptionBudget with --min-available or
+--max-unavailable pods.
+
+The Ingress serves --host (default: <app-name>.example.com, repeatable) on --path,
+with --ingress-class and --ingress-annotation. --tls-secret enables TLS and
+--cert-issuer lets cert-manager issue the certificate from a ClusterIssuer.
+--gateway [namespace/]name writes a Gateway API HTTPRoute attached to that Gateway
+instead of an Ingress, on the listener named by --gateway-section.
+
+--kind selects the workload: Deployment (default), StatefulSet with a volume per pod
+(--storage-size, --mount-path, --storage-class) and a headless Service, Job, CronJob
+(--schedule, --concurrency-policy) or DaemonSet. Jobs and CronJobs run to
+completion, so they get no probes, Service or Ingress.
+
+Pods are hardened to meet the Restricted Pod Security Standard: they run as a
+non-root user (--run-as-user, default: the user of the project's image) with a
+read-only root filesystem, no capabilities and the RuntimeDefault seccomp profile,
+under a dedicated ServiceAccount without an API token. --no-hardening leaves these
+to the cluster defaults. --network-policy adds a NetworkPolicy only admitting traffic
+from the ingress controller in --ingress-namespace.
+
+With --helm a Helm chart is written into charts/<app-name> instead, exposing the
+replicas, image, resources, service and ingress settings as chart values.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		appName := args[0]
+		// Get the output directory path
+		path, err := utils.GetAbsPath(outputDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		// Infer the settings of the project in the output directory, if any
+		project, detectErr := projects.DetectProject(path)
+		var settings k8s.ProjectSettings
+		if detectErr == nil {
+			if settings, err = k8s.InferSettings(project); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		appName := settings.AppName
+		if len(args) == 1 {
+			appName = args[0]
+		}
+		if appName == "" {
+			fmt.Printf("Error: no app name given and %v\n", detectErr)
+			os.Exit(1)
+		}
 
 		// Validate application name
 		if err := utils.ValidateProjectName(appName); err != nil {
@@ -35,6 +173,13 @@ Optionally include service and ingress resources.`,
 			os.Exit(1)
 		}
 
+		if settings.Port != 0 && !cmd.Flags().Changed("port") {
+			port = settings.Port
+		}
+		if imageRepository == "" {
+			imageRepository = settings.Image
+		}
+
 		// If container name is not provided, use app name
 		if containerName == "" {
 			containerName = appName
@@ -45,15 +190,57 @@ Optionally include service and ingress resources.`,
 			projectName = appName
 		}
 
-		// Get the output directory path
-		path, err := utils.GetAbsPath(outputDir, "")
-		if err != nil {
+		// Create k8s manifest generator with separate container and project names
+		generator := k8s.NewManifestGenerator(appName, projectName, containerName, namespace, port, createService, createIngress)
+		if helm && kustomize {
+			fmt.Println("Error: --helm and --kustomize cannot be used together")
+			os.Exit(1)
+		}
+		generator.Helm = helm
+		if err := configureKind(generator); err != nil {
 			fmt.Printf("Error: %v\n", err)
-			return
+			os.Exit(1)
+		}
+		if err := configureWorkload(generator); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if generator.Kind.Serving() {
+			generator.Probes = settings.Probes
+		}
+		if err := configureProbes(cmd, generator); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := configureEnv(generator); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		configureScaling(cmd, generator)
+		configureSecurity(cmd, generator, settings)
+		if err := configureRouting(generator); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if kustomize {
+			generator.Kustomize = true
+			if len(environments) == 0 {
+				fmt.Println("Error: --envs needs at least one environment")
+				os.Exit(1)
+			}
+			for _, env := range environments {
+				if err := k8s.ValidateEnvironment(env); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				generator.Environments = append(generator.Environments, generator.NewEnvironment(env))
+			}
 		}
 
-		// Create k8s manifest generator with separate container and project names
-		generator := k8s.NewManifestGenerator(appName, projectName, containerName, namespace, port, createService, createIngress)
+		if err := generator.Validate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Generate the manifests
 		if err := generator.Generate(path); err != nil {
@@ -61,10 +248,228 @@ Optionally include service and ingress resources.`,
 			return
 		}
 
+		if helm {
+			fmt.Printf("Helm chart for '%s' generated successfully at: %s\n", appName, filepath.Join(path, generator.ChartDir()))
+			fmt.Printf("Install it with: helm install %s %s --namespace %s --create-namespace\n", appName, generator.ChartDir(), namespace)
+			return
+		}
 		fmt.Printf("Kubernetes manifests for '%s' generated successfully at: %s\n", appName, path)
+		if len(generator.Secrets) > 0 && generator.SecretMode == k8s.PlainSecret {
+			fmt.Printf("%s holds %d secrets in clear text, keep it out of version control\n", generator.SecretFile(), len(generator.Secrets))
+		}
+		if kustomize {
+			fmt.Printf("Apply an environment with: kubectl apply -k k8s/overlays/%s\n", generator.Environments[0].Name)
+		}
 	},
 }
 
+// configureKind sets the workload kind and its StatefulSet or CronJob settings from
+// the flags
+func configureKind(generator *k8s.ManifestGenerator) error {
+	workloadKind, err := k8s.ParseKind(kind)
+	if err != nil {
+		return err
+	}
+	generator.Kind = workloadKind
+
+	generator.Storage = k8s.Storage{Size: storageSize, MountPath: mountPath, StorageClass: storageClass}
+	policy, err := k8s.ParseConcurrencyPolicy(concurrencyPolicy)
+	if err != nil {
+		return err
+	}
+	generator.Schedule = k8s.Schedule{Cron: schedule, ConcurrencyPolicy: policy}
+	return nil
+}
+
+// configureWorkload sets the image, replicas, pull policy and resources of the
+// generated workload from the flags
+func configureWorkload(generator *k8s.ManifestGenerator) error {
+	if imageRegistry == "" {
+		imageRegistry = os.Getenv(registryEnv)
+	}
+	generator.Image.Registry = imageRegistry
+	if imageRepository != "" {
+		generator.Image.Repository = imageRepository
+	}
+	generator.Image.Tag = imageTag
+	generator.Image.Digest = imageDigest
+	generator.Replicas = replicas
+
+	if pullPolicy != "" {
+		policy, err := k8s.ParsePullPolicy(pullPolicy)
+		if err != nil {
+			return err
+		}
+		generator.PullPolicy = policy
+	}
+
+	resources, err := k8s.ParseSize(size)
+	if err != nil {
+		return err
+	}
+	for _, override := range []struct {
+		value  string
+		target *string
+	}{
+		{cpuRequest, &resources.CPURequest},
+		{cpuLimit, &resources.CPULimit},
+		{memoryRequest, &resources.MemoryRequest},
+		{memoryLimit, &resources.MemoryLimit},
+	} {
+		if override.value != "" {
+			*override.target = override.value
+		}
+	}
+	generator.Resources = resources
+	return nil
+}
+
+// configureProbes sets the probes of the generated workload from the flags, starting
+// from the probes inferred from the project
+func configureProbes(cmd *cobra.Command, generator *k8s.ManifestGenerator) error {
+	if probeCheck != "" {
+		check, err := k8s.ParseProbe(probeCheck)
+		if err != nil {
+			return err
+		}
+		generator.Probes = k8s.Probes{}
+		if check != nil {
+			generator.Probes = k8s.NewProbes(*check)
+		}
+	}
+
+	for _, override := range []struct {
+		spec   string
+		target **k8s.Probe
+	}{
+		{livenessProbe, &generator.Probes.Liveness},
+		{readinessProbe, &generator.Probes.Readiness},
+		{startupProbe, &generator.Probes.Startup},
+	} {
+		if override.spec == "" {
+			continue
+		}
+		check, err := k8s.ParseProbe(override.spec)
+		if err != nil {
+			return err
+		}
+		*override.target = check
+	}
+
+	for _, probe := range generator.Probes.List() {
+		if probe.Type == k8s.GRPCProbe && probe.Port == 0 {
+			probe.Port = generator.Port
+		}
+		if cmd.Flags().Changed("probe-initial-delay") {
+			probe.InitialDelaySeconds = probeInitialDelay
+		}
+		if cmd.Flags().Changed("probe-period") {
+			probe.PeriodSeconds = probePeriod
+		}
+		if cmd.Flags().Changed("probe-timeout") {
+			probe.TimeoutSeconds = probeTimeout
+		}
+		if cmd.Flags().Changed("probe-failure-threshold") {
+			probe.FailureThreshold = probeFailureThreshold
+		}
+	}
+	return nil
+}
+
+// configureEnv splits the variables of the --env-file into the ConfigMap and the Secret
+func configureEnv(generator *k8s.ManifestGenerator) error {
+	mode, err := k8s.ParseSecretMode(secretMode)
+	if err != nil {
+		return err
+	}
+	generator.SecretMode = mode
+
+	if envFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(envFile); err != nil {
+		return fmt.Errorf("failed to read %s: %v", envFile, err)
+	}
+	vars, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
+	generator.Config, generator.Secrets, err = k8s.SplitEnv(vars, secretPatterns)
+	return err
+}
+
+// configureScaling adds the autoscaler and the disruption budget requested by the flags
+func configureScaling(cmd *cobra.Command, generator *k8s.ManifestGenerator) {
+	if hpa {
+		autoscaling := &k8s.Autoscaling{
+			MinReplicas:       generator.Replicas,
+			MaxReplicas:       maxReplicas,
+			CPUUtilization:    cpuUtilization,
+			MemoryUtilization: memoryUtilization,
+		}
+		if cmd.Flags().Changed("min-replicas") {
+			autoscaling.MinReplicas = minReplicas
+		}
+		generator.Autoscaling = autoscaling
+	}
+
+	if pdb {
+		budget := &k8s.DisruptionBudget{MinAvailable: minAvailable, MaxUnavailable: maxUnavailable}
+		if minAvailable == "" && maxUnavailable == "" {
+			budget.MaxUnavailable = "1"
+		}
+		generator.DisruptionBudget = budget
+	}
+}
+
+// configureRouting sets the hosts, path, class and TLS of the Ingress, or the Gateway
+// the HTTPRoute attaches to, from the flags
+func configureRouting(generator *k8s.ManifestGenerator) error {
+	if len(hosts) > 0 {
+		generator.Ingress.Hosts = hosts
+	}
+	generator.Ingress.Path = ingressPath
+	generator.Ingress.ClassName = ingressClass
+	generator.Ingress.Annotations = ingressAnnotations
+	generator.Ingress.TLSSecret = tlsSecret
+	generator.Ingress.ClusterIssuer = certIssuer
+
+	if gateway == "" {
+		if gatewaySection != "" {
+			return fmt.Errorf("--gateway-section needs --gateway")
+		}
+		return nil
+	}
+	parent, err := k8s.ParseGateway(gateway)
+	if err != nil {
+		return err
+	}
+	parent.SectionName = gatewaySection
+	generator.Gateway = parent
+	// The route replaces the Ingress
+	generator.WithIngress = true
+	return nil
+}
+
+// configureSecurity sets the hardened profile and the network policy from the flags
+func configureSecurity(cmd *cobra.Command, generator *k8s.ManifestGenerator, settings k8s.ProjectSettings) {
+	generator.Security = nil
+	if !noHardening {
+		security := &k8s.Security{RunAsUser: k8s.DefaultUser}
+		if settings.RunAsUser != 0 {
+			security.RunAsUser = settings.RunAsUser
+		}
+		if cmd.Flags().Changed("run-as-user") {
+			security.RunAsUser = runAsUser
+		}
+		generator.Security = security
+	}
+
+	if networkPolicy {
+		generator.NetworkPolicy = &k8s.NetworkPolicy{IngressNamespace: ingressNamespace}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(k8sCmd)
 
@@ -72,8 +477,59 @@ func init() {
 	k8sCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace for the application")
 	k8sCmd.Flags().BoolVarP(&createService, "service", "s", false, "Create a Kubernetes Service manifest")
 	k8sCmd.Flags().BoolVarP(&createIngress, "ingress", "i", false, "Create a Kubernetes Ingress manifest")
-	k8sCmd.Flags().IntVarP(&port, "port", "p", 8080, "Container port for the application")
+	k8sCmd.Flags().IntVarP(&port, "port", "p", 8080, "Container port for the application, inferred from the project when not set")
 	k8sCmd.Flags().StringVarP(&outputDir, "output", "o", ".", "Output directory for the manifest files")
 	k8sCmd.Flags().StringVarP(&containerName, "container-name", "c", "", "Container name (defaults to app-name if not provided)")
 	k8sCmd.Flags().StringVarP(&projectName, "project-name", "r", "", "Project name for labels and selectors (defaults to app-name if not provided)")
+	k8sCmd.Flags().BoolVar(&kustomize, "kustomize", false, "Write a Kustomize base and an overlay per environment")
+	k8sCmd.Flags().BoolVar(&helm, "helm", false, "Write a Helm chart into charts/<app-name> instead of the manifests")
+	k8sCmd.Flags().StringSliceVar(&environments, "envs", k8s.DefaultEnvironments, "Environments to write Kustomize overlays for")
+	k8sCmd.Flags().StringVar(&imageRegistry, "registry", "", "Image registry, like ghcr.io/acme (default: $"+registryEnv+")")
+	k8sCmd.Flags().StringVar(&imageRepository, "image", "", "Image repository (defaults to the Dockerfile title label or the container name)")
+	k8sCmd.Flags().StringVar(&imageTag, "tag", "latest", "Image tag")
+	k8sCmd.Flags().StringVar(&imageDigest, "digest", "", "Image digest like sha256:<hex>, pinning the image instead of the tag")
+	k8sCmd.Flags().IntVar(&replicas, "replicas", 1, "Number of replicas")
+	k8sCmd.Flags().StringVar(&pullPolicy, "pull-policy", "", "Image pull policy: Always, IfNotPresent or Never (default: cluster default)")
+	k8sCmd.Flags().StringVar(&size, "size", k8s.DefaultSize, "Resource preset: small, medium or large")
+	k8sCmd.Flags().StringVar(&cpuRequest, "cpu-request", "", "CPU request, overriding the size preset")
+	k8sCmd.Flags().StringVar(&cpuLimit, "cpu-limit", "", "CPU limit, overriding the size preset")
+	k8sCmd.Flags().StringVar(&memoryRequest, "memory-request", "", "Memory request, overriding the size preset")
+	k8sCmd.Flags().StringVar(&memoryLimit, "memory-limit", "", "Memory limit, overriding the size preset")
+	k8sCmd.Flags().StringVar(&probeCheck, "probe", "", "Check run by every probe: http:/path, tcp[:port], grpc[:port[:service]], exec:<command> or none (default: the project's health endpoint)")
+	k8sCmd.Flags().StringVar(&livenessProbe, "liveness", "", "Liveness probe check, overriding --probe")
+	k8sCmd.Flags().StringVar(&readinessProbe, "readiness", "", "Readiness probe check, overriding --probe")
+	k8sCmd.Flags().StringVar(&startupProbe, "startup", "", "Startup probe check, overriding --probe")
+	k8sCmd.Flags().IntVar(&probeInitialDelay, "probe-initial-delay", 0, "Seconds before the probes start")
+	k8sCmd.Flags().IntVar(&probePeriod, "probe-period", 0, "Seconds between two probe checks")
+	k8sCmd.Flags().IntVar(&probeTimeout, "probe-timeout", 0, "Seconds after which a probe check times out")
+	k8sCmd.Flags().IntVar(&probeFailureThreshold, "probe-failure-threshold", 0, "Failed checks after which a probe fails")
+	k8sCmd.Flags().StringVar(&envFile, "env-file", "", "Load a .env file into a ConfigMap and a Secret")
+	k8sCmd.Flags().StringSliceVar(&secretPatterns, "secret-patterns", k8s.DefaultSecretPatterns, "Key patterns of the variables written into the Secret")
+	k8sCmd.Flags().BoolVar(&hpa, "hpa", false, "Add a HorizontalPodAutoscaler")
+	k8sCmd.Flags().IntVar(&minReplicas, "min-replicas", 0, "Minimum replicas of the autoscaler (default: --replicas)")
+	k8sCmd.Flags().IntVar(&maxReplicas, "max-replicas", 5, "Maximum replicas of the autoscaler")
+	k8sCmd.Flags().IntVar(&cpuUtilization, "cpu-utilization", 80, "Target CPU utilization in percent of the requests, 0 to disable")
+	k8sCmd.Flags().IntVar(&memoryUtilization, "memory-utilization", 0, "Target memory utilization in percent of the requests, 0 to disable")
+	k8sCmd.Flags().BoolVar(&pdb, "pdb", false, "Add a PodDisruptionBudget")
+	k8sCmd.Flags().StringVar(&minAvailable, "min-available", "", "Pods kept available during disruptions, a number or a percentage")
+	k8sCmd.Flags().StringVar(&maxUnavailable, "max-unavailable", "", "Pods taken down at once during disruptions, a number or a percentage (default: 1)")
+	k8sCmd.Flags().StringSliceVar(&hosts, "host", nil, "Host served by the Ingress or HTTPRoute, repeatable (default: <app-name>.example.com)")
+	k8sCmd.Flags().StringVar(&ingressPath, "path", "/", "Path prefix routed to the Service")
+	k8sCmd.Flags().StringVar(&ingressClass, "ingress-class", "", "Ingress class name (default: the cluster default)")
+	k8sCmd.Flags().StringToStringVar(&ingressAnnotations, "ingress-annotation", nil, "Ingress annotation as key=value, repeatable")
+	k8sCmd.Flags().StringVar(&tlsSecret, "tls-secret", "", "Secret holding the TLS certificate of the hosts, enabling TLS")
+	k8sCmd.Flags().StringVar(&certIssuer, "cert-issuer", "", "cert-manager ClusterIssuer issuing the certificate into --tls-secret")
+	k8sCmd.Flags().StringVar(&gateway, "gateway", "", "Gateway as [namespace/]name, writing an HTTPRoute instead of an Ingress")
+	k8sCmd.Flags().StringVar(&gatewaySection, "gateway-section", "", "Listener of the Gateway the HTTPRoute attaches to")
+	k8sCmd.Flags().StringVar(&kind, "kind", string(k8s.Deployment), "Workload kind: Deployment, StatefulSet, Job, CronJob or DaemonSet")
+	k8sCmd.Flags().StringVar(&schedule, "schedule", "", "Cron schedule of a CronJob, like \"*/15 * * * *\" or @hourly")
+	k8sCmd.Flags().StringVar(&concurrencyPolicy, "concurrency-policy", "Forbid", "What a CronJob does when the previous run is still active: Allow, Forbid or Replace")
+	k8sCmd.Flags().StringVar(&storageSize, "storage-size", "1Gi", "Size of the volume claimed by each StatefulSet pod")
+	k8sCmd.Flags().StringVar(&mountPath, "mount-path", "/data", "Mount path of the StatefulSet volume")
+	k8sCmd.Flags().StringVar(&storageClass, "storage-class", "", "Storage class of the StatefulSet volume (default: the cluster default)")
+	k8sCmd.Flags().BoolVar(&noHardening, "no-hardening", false, "Leave the security context and ServiceAccount of the pods to the cluster defaults")
+	k8sCmd.Flags().IntVar(&runAsUser, "run-as-user", 0, "Numeric user of the containers (default: the user of the project's image)")
+	k8sCmd.Flags().BoolVar(&networkPolicy, "network-policy", false, "Add a NetworkPolicy only admitting traffic from the ingress controller")
+	k8sCmd.Flags().StringVar(&ingressNamespace, "ingress-namespace", k8s.DefaultIngressNamespace, "Namespace of the ingress controller or Gateway admitted by the NetworkPolicy")
+	k8sCmd.Flags().StringVar(&secretMode, "secrets", string(k8s.PlainSecret), "Secret manifest: plain, sealed (SealedSecret) or external (ExternalSecret)")
 }
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/license"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	licenseProjectDir string = "."   // project to add the LICENSE to
+	licenseHolder     string = ""    // copyright holder, defaults to git user.name
+	licenseForce      bool   = false // overwrite an existing LICENSE
+)
+
+// licenseCmd represents the license command
+var licenseCmd = &cobra.Command{
+	Use:   "license [license]",
+	Short: "Add a LICENSE file to a project",
+	Long: `Add a LICENSE file with the full license text, dated the current year and naming
+the copyright holder (git user.name unless --holder is given). The license field of
+package.json and pyproject.toml is updated and a badge is added to README.md.
+
+Supported licenses: ` + strings.Join(license.IDs(), ", ") + `
+
+Example:
+  initiator license MIT --holder "Jane Doe"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(licenseProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var l license.License
+		if len(args) == 1 {
+			if l, err = license.Parse(args[0]); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			l = license.Prompt()
+		}
+
+		if err := writeLicense(path, l, licenseHolder, licenseForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// writeLicense writes the LICENSE file of the project at dir and references it from
+// the project files. An empty holder falls back to git user.name.
+func writeLicense(dir string, l license.License, holder string, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+
+	if holder == "" {
+		holder = license.DefaultHolder(dir)
+	}
+
+	writer := license.NewWriter(dir, l, holder, force)
+	if err := writer.Write(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s LICENSE (%s) created for %s\n", green("✓"), l.ID, holder)
+	if len(writer.Updated) > 0 {
+		fmt.Printf("%s License referenced in: %s\n", green("✓"), strings.Join(writer.Updated, ", "))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(licenseCmd)
+
+	licenseCmd.Flags().StringVarP(&licenseProjectDir, "dir", "d", ".", "project directory to add the LICENSE to")
+	licenseCmd.Flags().StringVar(&licenseHolder, "holder", "", "copyright holder (defaults to git user.name)")
+	licenseCmd.Flags().BoolVarP(&licenseForce, "force", "f", false, "overwrite an existing LICENSE")
+}
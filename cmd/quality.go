@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/quality"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	qualityProjectDir string = "."                       // project to add the tooling to
+	qualityHooks      string = string(quality.PreCommit) // hook manager running the checks
+	qualityForce      bool   = false                     // overwrite existing configuration files
+)
+
+// qualityCmd represents the quality command
+var qualityCmd = &cobra.Command{
+	Use:   "quality",
+	Short: "Add linter, formatter and Git hook configuration to a project",
+	Long: `Add the standard quality tooling to a project: .editorconfig, .golangci.yml for Go
+projects, Prettier with format and lint scripts for Node.js projects, and a
+.pre-commit-config.yaml (or lefthook.yml with --hooks lefthook) running the checks
+before every commit.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(qualityProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		hooks, err := quality.ParseHookManager(qualityHooks)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := generateQualityTooling(path, hooks, qualityForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generateQualityTooling writes the linter, formatter and hook configuration of the
+// project at dir. Full-stack projects get one hook configuration covering api/ and web/.
+func generateQualityTooling(dir string, hooks quality.HookManager, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	apps, err := projects.DetectApps(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := quality.NewGenerator(dir, apps, hooks, force)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Linter, formatter and %s configuration generated at: %s\n", green("✓"), hooks, dir)
+	if len(generator.AddedScripts) > 0 {
+		fmt.Printf("%s Added package.json scripts: %s\n", green("✓"), strings.Join(generator.AddedScripts, ", "))
+	}
+	for _, warning := range generator.Warnings {
+		fmt.Printf("%s Manual step: %s\n", yellow("!"), warning)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(qualityCmd)
+
+	qualityCmd.Flags().StringVarP(&qualityProjectDir, "dir", "d", ".", "project directory to add the tooling to")
+	qualityCmd.Flags().StringVar(&qualityHooks, "hooks", string(quality.PreCommit), "Git hook manager (pre-commit or lefthook)")
+	qualityCmd.Flags().BoolVarP(&qualityForce, "force", "f", false, "overwrite existing configuration files")
+}
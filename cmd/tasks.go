@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/tasks"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tasksProjectDir string = "."    // project to generate the task file for
+	tasksFormat     string = "make" // make or task
+	tasksForce      bool   = false  // overwrite an existing task file
+)
+
+// tasksCmd represents the tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Generate a Makefile or Taskfile.yml for a project",
+	Long: `Generate a Makefile or Taskfile.yml with build, run, test, lint and clean targets
+matching the project variant. Docker, Docker Compose and Kubernetes targets are added
+when the project has a Dockerfile, a compose.yaml or a k8s/ directory, and a help
+target lists every target.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := utils.GetAbsPath(tasksProjectDir, "")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		format, err := tasks.ParseFormat(tasksFormat)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := generateTaskFile(path, format, tasksForce); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// generateTaskFile writes the Makefile or Taskfile.yml of the project at dir
+func generateTaskFile(dir string, format tasks.Format, force bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	if projects.IsFullStack(dir) {
+		return fmt.Errorf("full-stack projects already have a Makefile running the API and the frontend")
+	}
+
+	info, err := projects.DetectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	generator := tasks.NewGenerator(info, format, force)
+	if err := generator.Generate(); err != nil {
+		return err
+	}
+
+	help := "make help"
+	if format == tasks.Task {
+		help = "task --list"
+	}
+	fmt.Printf("%s %s generated at: %s\n", green("✓"), generator.FileName(), filepath.Join(dir, generator.FileName()))
+	fmt.Printf("List the targets with: %s\n", cyan(help))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(tasksCmd)
+
+	tasksCmd.Flags().StringVarP(&tasksProjectDir, "dir", "d", ".", "project directory to generate the task file in")
+	tasksCmd.Flags().StringVar(&tasksFormat, "format", "make", "task file format (make or task)")
+	tasksCmd.Flags().BoolVarP(&tasksForce, "force", "f", false, "overwrite an existing task file")
+}
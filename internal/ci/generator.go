@@ -0,0 +1,210 @@
+package ci
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+)
+
+// Provider is a CI service a pipeline can be generated for
+type Provider string
+
+const (
+	GitHub Provider = "github"
+	GitLab Provider = "gitlab"
+)
+
+// ParseProvider validates a provider name
+func ParseProvider(name string) (Provider, error) {
+	switch provider := Provider(strings.ToLower(name)); provider {
+	case GitHub, GitLab:
+		return provider, nil
+	default:
+		return "", fmt.Errorf("unsupported CI provider %q, use %s or %s", name, GitHub, GitLab)
+	}
+}
+
+// DefaultBranch is the branch whose pushes run the pipeline when none is given
+const DefaultBranch = "main"
+
+// ManifestsDir is the directory holding the Kubernetes manifests validated in CI
+const ManifestsDir = "k8s"
+
+// App is a project built by the pipeline, full-stack projects contain two apps
+type App struct {
+	Project *projects.ProjectInfo
+	// Job is the name of the CI job building the app
+	Job string
+	// Path is the app directory relative to the repository root
+	Path string
+	// Image is the name of the Docker image built from the app
+	Image string
+	// Docker adds a job building the Docker image of the app
+	Docker bool
+}
+
+// Generator generates a CI pipeline for one or more apps of a repository
+type Generator struct {
+	Dir      string
+	Provider Provider
+	Apps     []App
+	// K8s adds a job validating the Kubernetes manifests
+	K8s bool
+	// Branch is the default branch, whose pushes run the GitHub workflow
+	Branch string
+	// Force overwrites an existing pipeline
+	Force bool
+}
+
+// NewGenerator creates a new Generator writing the pipeline into dir
+func NewGenerator(dir string, provider Provider, apps []App, k8s bool, force bool) *Generator {
+	return &Generator{
+		Dir:      dir,
+		Provider: provider,
+		Apps:     apps,
+		K8s:      k8s,
+		Branch:   DefaultBranch,
+		Force:    force,
+	}
+}
+
+// NewApp describes the project at dir, relative to the repository root at root.
+// The Docker job is added when the project has a Dockerfile.
+func NewApp(root string, project *projects.ProjectInfo, job string, image string) (App, error) {
+	rel, err := filepath.Rel(root, project.Dir)
+	if err != nil {
+		return App{}, fmt.Errorf("failed to resolve %s: %v", project.Dir, err)
+	}
+	if image == "" {
+		image = docker.DefaultImageName(project.Dir)
+	}
+
+	_, err = os.Stat(filepath.Join(project.Dir, "Dockerfile"))
+	return App{
+		Project: project,
+		Job:     job,
+		Path:    filepath.ToSlash(rel),
+		Image:   image,
+		Docker:  err == nil,
+	}, nil
+}
+
+// appData holds the values rendered for a single app
+type appData struct {
+	App
+	Go             bool
+	Bun            bool
+	Corepack       bool
+	PackageManager projects.PackageManager
+	Lockfile       string
+	Install        string
+	Scripts        []script
+}
+
+// File returns the path of a file of the app relative to the repository root
+func (a appData) File(name string) string {
+	return path.Join(a.Path, name)
+}
+
+// script is a package.json script run in CI
+type script struct {
+	Name    string
+	Command string
+}
+
+// templateData holds the values rendered into the pipeline
+type templateData struct {
+	Apps         []appData
+	Branch       string
+	HasDocker    bool
+	K8s          bool
+	ManifestsDir string
+}
+
+// Path returns the location of the pipeline file relative to the repository root
+func (g *Generator) Path() string {
+	if g.Provider == GitLab {
+		return ".gitlab-ci.yml"
+	}
+	return filepath.Join(".github", "workflows", "ci.yml")
+}
+
+// Generate writes the pipeline file. It refuses to overwrite an existing one unless Force is set.
+func (g *Generator) Generate() error {
+	target := filepath.Join(g.Dir, g.Path())
+	if !g.Force {
+		if _, err := os.Stat(target); err == nil {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", g.Path())
+		}
+	}
+
+	content, err := g.Render()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(g.Path()), err)
+	}
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create %s: %v", g.Path(), err)
+	}
+	return nil
+}
+
+// Render returns the content of the pipeline file
+func (g *Generator) Render() (string, error) {
+	data := templateData{Branch: g.Branch, K8s: g.K8s, ManifestsDir: ManifestsDir}
+	for _, app := range g.Apps {
+		data.Apps = append(data.Apps, g.appData(app))
+		data.HasDocker = data.HasDocker || app.Docker
+	}
+
+	content := githubTemplate
+	if g.Provider == GitLab {
+		content = gitlabTemplate
+	}
+
+	tmpl, err := template.New(string(g.Provider)).Delims("[[", "]]").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", g.Provider, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %v", g.Path(), err)
+	}
+	return buf.String(), nil
+}
+
+// appData derives the setup, install and script steps of an app
+func (g *Generator) appData(app App) appData {
+	data := appData{App: app, Go: app.Project.Type == projects.GoLang}
+	if data.Go {
+		return data
+	}
+
+	pm := app.Project.PackageManager.OrDefault()
+	data.PackageManager = pm
+	data.Bun = pm == projects.Bun
+	data.Corepack = pm == projects.PNPM || pm == projects.Yarn
+	data.Lockfile = pm.LockfileIn(app.Project.Dir)
+	data.Install = strings.Join(pm.InstallFrozen().Args, " ")
+
+	for _, name := range []string{"lint", "test", "build"} {
+		if app.Project.HasScript(name) {
+			data.Scripts = append(data.Scripts, script{
+				Name:    strings.ToUpper(name[:1]) + name[1:],
+				Command: pm.Run(name),
+			})
+		}
+	}
+	return data
+}
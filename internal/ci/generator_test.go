@@ -0,0 +1,127 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerate_GitHubGo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	app, err := NewApp(dir, project, "build", "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generator := NewGenerator(dir, GitHub, []App{app}, true, false)
+	generator.Branch = "trunk"
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	content := readPipeline(t, filepath.Join(dir, ".github", "workflows", "ci.yml"))
+	for _, snippet := range []string{
+		"  push:\n    branches:\n      - trunk\n",
+		"go-version-file: go.mod",
+		"run: go vet ./...",
+		"  build-docker:\n    runs-on: ubuntu-latest\n    needs: build",
+		"tags: api:${{ github.sha }}",
+		"ghcr.io/yannh/kubeconform:latest",
+	} {
+		if !strings.Contains(content, snippet) {
+			t.Errorf("expected ci.yml to contain %q, got:\n%s", snippet, content)
+		}
+	}
+	if strings.Contains(content, "working-directory") {
+		t.Errorf("expected no working directory for a project at the repository root, got:\n%s", content)
+	}
+
+	if err := generator.Generate(); err == nil {
+		t.Fatal("expected an error when the pipeline already exists")
+	}
+}
+
+func TestGenerate_GitLabFullStack(t *testing.T) {
+	root := t.TempDir()
+	api := &projects.ProjectInfo{Dir: filepath.Join(root, "api"), Type: projects.GoLang, GoType: projects.WebGo}
+	web := &projects.ProjectInfo{Dir: filepath.Join(root, "web"), Type: projects.NodeJS, NodeType: projects.ViteReact, PackageManager: projects.PNPM}
+
+	var apps []App
+	for _, app := range []struct {
+		project *projects.ProjectInfo
+		job     string
+	}{{api, "api"}, {web, "web"}} {
+		a, err := NewApp(root, app.project, app.job, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		apps = append(apps, a)
+	}
+
+	content, err := NewGenerator(root, GitLab, apps, false, false).Render()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var pipeline map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &pipeline); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+
+	for _, snippet := range []string{
+		"    - cd api\n    - go vet ./...",
+		"        - web/pnpm-lock.yaml",
+		"    - corepack enable",
+		"    - pnpm install --frozen-lockfile",
+	} {
+		if !strings.Contains(content, snippet) {
+			t.Errorf("expected .gitlab-ci.yml to contain %q, got:\n%s", snippet, content)
+		}
+	}
+	if strings.Contains(content, "docker") || strings.Contains(content, "kubeconform") {
+		t.Errorf("expected no docker or k8s jobs, got:\n%s", content)
+	}
+}
+
+func TestGenerate_GitLabBunLockb(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bun.lockb"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cache key follows the lockfile of the project, not the one Bun writes today
+	project := &projects.ProjectInfo{Dir: dir, Type: projects.NodeJS, NodeType: projects.Express, PackageManager: projects.Bun}
+	app, err := NewApp(dir, project, "build", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := NewGenerator(dir, GitLab, []App{app}, false, false).Render()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(content, "        - bun.lockb\n") {
+		t.Errorf("expected the cache key to use bun.lockb, got:\n%s", content)
+	}
+}
+
+// readPipeline reads a generated pipeline and checks that it is valid YAML
+func readPipeline(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	var pipeline map[string]interface{}
+	if err := yaml.Unmarshal(content, &pipeline); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+	return string(content)
+}
@@ -0,0 +1,152 @@
+package ci
+
+// The templates use [[ ]] delimiters because GitHub Actions expressions use ${{ }}.
+
+const githubTemplate = `name: CI
+
+on:
+  push:
+    branches:
+      - [[.Branch]]
+  pull_request:
+
+jobs:
+[[- range .Apps]]
+  [[.Job]]:
+    runs-on: ubuntu-latest
+[[- if ne .Path "."]]
+    defaults:
+      run:
+        working-directory: [[.Path]]
+[[- end]]
+    steps:
+      - uses: actions/checkout@v4
+[[- if .Go]]
+      - uses: actions/setup-go@v5
+        with:
+          go-version-file: [[.File "go.mod"]]
+      - name: Vet
+        run: go vet ./...
+      - name: Test
+        run: go test ./...
+      - name: Build
+        run: go build ./...
+[[- else]]
+[[- if .Bun]]
+      - uses: oven-sh/setup-bun@v2
+[[- else]]
+[[- if .Corepack]]
+      - name: Enable Corepack
+        run: corepack enable
+[[- end]]
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 22
+          cache: [[.PackageManager]]
+          cache-dependency-path: [[.File .Lockfile]]
+[[- end]]
+      - name: Install
+        run: [[.Install]]
+[[- range .Scripts]]
+      - name: [[.Name]]
+        run: [[.Command]]
+[[- end]]
+[[- end]]
+[[- if .Docker]]
+
+  [[.Job]]-docker:
+    runs-on: ubuntu-latest
+    needs: [[.Job]]
+    steps:
+      - uses: actions/checkout@v4
+      - uses: docker/setup-buildx-action@v3
+      - name: Build image
+        uses: docker/build-push-action@v6
+        with:
+          context: [[.Path]]
+          push: false
+          tags: [[.Image]]:${{ github.sha }}
+[[- end]]
+[[- end]]
+[[- if .K8s]]
+
+  k8s:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Validate manifests
+        run: |
+          docker run --rm -v "$PWD/[[.ManifestsDir]]:/manifests" ghcr.io/yannh/kubeconform:latest \
+            -strict -summary -ignore-missing-schemas /manifests
+[[- end]]
+`
+
+const gitlabTemplate = `stages:
+  - test
+[[- if .HasDocker]]
+  - build
+[[- end]]
+[[- if .K8s]]
+  - validate
+[[- end]]
+[[- range .Apps]]
+
+[[.Job]]:
+  stage: test
+[[- if .Go]]
+  image: golang:1
+  script:
+[[- if ne .Path "."]]
+    - cd [[.Path]]
+[[- end]]
+    - go vet ./...
+    - go test ./...
+    - go build ./...
+[[- else]]
+  image: [[if .Bun]]oven/bun:1[[else]]node:22[[end]]
+  cache:
+    key:
+      files:
+        - [[.File .Lockfile]]
+    paths:
+      - [[.File "node_modules"]]/
+[[- if .Corepack]]
+  before_script:
+    - corepack enable
+[[- end]]
+  script:
+[[- if ne .Path "."]]
+    - cd [[.Path]]
+[[- end]]
+    - [[.Install]]
+[[- range .Scripts]]
+    - [[.Command]]
+[[- end]]
+[[- end]]
+[[- if .Docker]]
+
+[[.Job]]-docker:
+  stage: build
+  image: docker:27
+  services:
+    - docker:27-dind
+  variables:
+    DOCKER_TLS_CERTDIR: "/certs"
+  needs:
+    - [[.Job]]
+  script:
+    - docker build -t [[.Image]]:$CI_COMMIT_SHORT_SHA [[.Path]]
+[[- end]]
+[[- end]]
+[[- if .K8s]]
+
+k8s:
+  stage: validate
+  image:
+    name: ghcr.io/yannh/kubeconform:latest-alpine
+    entrypoint: [""]
+  needs: []
+  script:
+    - /kubeconform -strict -summary -ignore-missing-schemas [[.ManifestsDir]]/
+[[- end]]
+`
@@ -0,0 +1,133 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// Generator generates a compose.yaml running the project next to its backing services
+type Generator struct {
+	Project   *projects.ProjectInfo
+	Services  []Service
+	ImageName string
+	Port      int
+	// Force overwrites an existing compose.yaml
+	Force bool
+	// Warnings lists steps the user has to complete by hand
+	Warnings []string
+	// AddedEnv lists the variables added to .env
+	AddedEnv []string
+}
+
+// NewGenerator creates a new Generator for the project and the selected backing services.
+// The image name and port follow the Dockerfile generated by the docker package.
+func NewGenerator(project *projects.ProjectInfo, services []Service, force bool) *Generator {
+	return &Generator{
+		Project:   project,
+		Services:  services,
+		ImageName: docker.DefaultImageName(project.Dir),
+		Port:      docker.DefaultPort(project),
+		Force:     force,
+	}
+}
+
+// templateData holds the values rendered into compose.yaml
+type templateData struct {
+	ImageName      string
+	Port           int
+	Services       []Service
+	AppEnvironment []utils.EnvVar
+}
+
+// Generate writes compose.yaml and adds the connection variables of the backing
+// services to .env, keeping the values already defined there.
+func (g *Generator) Generate() error {
+	composePath := filepath.Join(g.Project.Dir, "compose.yaml")
+	if !g.Force {
+		for _, name := range []string{"compose.yaml", "docker-compose.yml", "docker-compose.yaml"} {
+			if _, err := os.Stat(filepath.Join(g.Project.Dir, name)); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	content, err := g.Render()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(composePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create compose.yaml: %v", err)
+	}
+
+	if err := g.writeEnv(); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(g.Project.Dir, "Dockerfile")); err != nil {
+		g.Warnings = append(g.Warnings, "generate the Dockerfile the app service is built from with `initiator docker`")
+	}
+	return nil
+}
+
+// Render returns the content of compose.yaml
+func (g *Generator) Render() (string, error) {
+	data := templateData{
+		ImageName: g.ImageName,
+		Port:      g.Port,
+		Services:  g.Services,
+	}
+	for _, service := range g.Services {
+		data.AppEnvironment = append(data.AppEnvironment, service.AppEnvironment...)
+	}
+
+	tmpl, err := template.New("compose").Parse(composeTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse compose template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render compose.yaml: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// writeEnv appends the variables of each service to .env. References like ${DB_USER}
+// are expanded with the values in .env so connection URLs match the credentials
+// the services are started with.
+func (g *Generator) writeEnv() error {
+	envPath := filepath.Join(g.Project.Dir, ".env")
+
+	existing, err := utils.ReadEnvFile(envPath)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string, len(existing))
+	for _, v := range existing {
+		values[v.Key] = v.Value
+	}
+
+	for _, service := range g.Services {
+		var vars []utils.EnvVar
+		for _, v := range service.DotEnv {
+			if _, ok := values[v.Key]; !ok {
+				values[v.Key] = os.Expand(v.Value, func(key string) string { return values[key] })
+			}
+			vars = append(vars, utils.EnvVar{Key: v.Key, Value: values[v.Key]})
+		}
+
+		added, err := utils.AppendEnvFile(envPath, service.Description, vars)
+		if err != nil {
+			return err
+		}
+		g.AddedEnv = append(g.AddedEnv, added...)
+	}
+	return nil
+}
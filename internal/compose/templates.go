@@ -0,0 +1,58 @@
+package compose
+
+const composeTemplate = `services:
+  app:
+    build: .
+    image: {{.ImageName}}
+{{- if .Port}}
+    ports:
+      - "{{.Port}}:{{.Port}}"
+{{- end}}
+    env_file:
+      - .env
+{{- if .AppEnvironment}}
+    environment:
+{{- range .AppEnvironment}}
+      {{.Key}}: {{.Value}}
+{{- end}}
+{{- end}}
+{{- if .Services}}
+    depends_on:
+{{- range .Services}}
+      {{.Name}}:
+        condition: service_healthy
+{{- end}}
+{{- end}}
+{{- range .Services}}
+
+  {{.Name}}:
+    image: {{.Image}}
+{{- if .Command}}
+    command: {{.Command}}
+{{- end}}
+{{- if .Environment}}
+    environment:
+{{- range .Environment}}
+      {{.Key}}: {{.Value}}
+{{- end}}
+{{- end}}
+    ports:
+{{- range .Ports}}
+      - "{{.}}"
+{{- end}}
+    volumes:
+      - {{.Volume}}:{{.DataDir}}
+    healthcheck:
+      test: {{.Healthcheck}}
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- end}}
+{{- if .Services}}
+
+volumes:
+{{- range .Services}}
+  {{.Volume}}:
+{{- end}}
+{{- end}}
+`
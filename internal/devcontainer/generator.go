@@ -0,0 +1,373 @@
+package devcontainer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/pkg"
+)
+
+// Default toolchain versions used when they cannot be detected
+const (
+	DefaultGoVersion   = "1.23"
+	DefaultNodeVersion = "22"
+)
+
+// Generator generates the dev container and VS Code configuration of a project.
+// Full-stack projects pass the API and the frontend as separate projects, their
+// configuration is merged into a single dev container.
+type Generator struct {
+	Dir      string
+	Projects []*projects.ProjectInfo
+	// GoVersion is the major.minor Go version, read from go.mod
+	GoVersion string
+	// NodeVersion is the major Node.js version, taken from the installed Node.js
+	NodeVersion string
+	// DockerInDocker adds the Docker-in-Docker feature so compose.yaml can be run
+	DockerInDocker bool
+	// Force overwrites existing files
+	Force bool
+}
+
+// NewGenerator creates a new Generator for the projects of dir. The Go version is read
+// from go.mod, the Node.js version from the installed Node.js and Docker-in-Docker is
+// enabled when the project has a compose.yaml.
+func NewGenerator(dir string, apps []*projects.ProjectInfo, force bool) *Generator {
+	g := &Generator{
+		Dir:         dir,
+		Projects:    apps,
+		GoVersion:   DefaultGoVersion,
+		NodeVersion: DefaultNodeVersion,
+		Force:       force,
+	}
+
+	for _, project := range apps {
+		if project.Type == projects.GoLang {
+			if version := goVersion(project.Dir); version != "" {
+				g.GoVersion = version
+			}
+		}
+	}
+	if version, err := pkg.GetNodeVersion(); err == nil && version != "" {
+		g.NodeVersion = strings.SplitN(version, ".", 2)[0]
+	}
+	if _, err := os.Stat(filepath.Join(dir, "compose.yaml")); err == nil {
+		g.DockerInDocker = true
+	}
+	return g
+}
+
+// goVersion returns the major.minor version of the go directive in go.mod
+func goVersion(dir string) string {
+	file, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "go" {
+			parts := strings.SplitN(fields[1], ".", 3)
+			if len(parts) >= 2 {
+				return parts[0] + "." + parts[1]
+			}
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// Generate writes the files returned by Files into the project directory.
+// It refuses to overwrite existing files unless Force is set.
+func (g *Generator) Generate() error {
+	files, err := g.Files()
+	if err != nil {
+		return err
+	}
+
+	if !g.Force {
+		for name := range files {
+			if _, err := os.Stat(filepath.Join(g.Dir, name)); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	for name, content := range files {
+		target := filepath.Join(g.Dir, name)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", filepath.Dir(name), err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Files returns the generated files keyed by their path relative to the project:
+// the dev container definition and the VS Code launch configurations, tasks and
+// recommended extensions.
+func (g *Generator) Files() (map[string]string, error) {
+	documents := map[string]interface{}{
+		filepath.Join(".devcontainer", "devcontainer.json"): g.DevContainer(),
+		filepath.Join(".vscode", "launch.json"):             launchFile{Version: "0.2.0", Configurations: g.LaunchConfigurations()},
+		filepath.Join(".vscode", "tasks.json"):              tasksFile{Version: "2.0.0", Tasks: g.Tasks()},
+		filepath.Join(".vscode", "extensions.json"):         extensionsFile{Recommendations: g.Extensions()},
+	}
+
+	files := map[string]string{}
+	for name, document := range documents {
+		content, err := encode(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %v", name, err)
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+// DevContainer returns the dev container definition. Go projects use the Go image,
+// Node.js projects the TypeScript image, and full-stack projects the Go image with
+// the Node.js feature.
+func (g *Generator) DevContainer() DevContainer {
+	container := DevContainer{
+		Name:     docker.DefaultImageName(g.Dir),
+		Features: map[string]map[string]string{},
+	}
+
+	var postCreate []string
+	for _, project := range g.Projects {
+		var command string
+		if project.Type == projects.GoLang {
+			command = "go mod download"
+		} else {
+			pm := project.PackageManager.OrDefault()
+			install := strings.Join(pm.Install().Args, " ")
+			if pm == projects.PNPM || pm == projects.Bun {
+				// The images ship npm and yarn, npm's global prefix is writable by the dev user
+				install = "npm install -g " + string(pm) + " && " + install
+			}
+			command = install
+		}
+		if rel := project.RelativePath(g.Dir); rel != "." {
+			// A subshell keeps the next command in the workspace root
+			command = "(cd " + rel + " && " + command + ")"
+		}
+		postCreate = append(postCreate, command)
+
+		if port := devPort(project); port != 0 {
+			container.ForwardPorts = append(container.ForwardPorts, port)
+		}
+	}
+	container.PostCreateCommand = strings.Join(postCreate, " && ")
+
+	switch {
+	case projects.HasProjectType(g.Projects, projects.GoLang) && projects.HasProjectType(g.Projects, projects.NodeJS):
+		container.Image = fmt.Sprintf("mcr.microsoft.com/devcontainers/go:1-%s-bookworm", g.GoVersion)
+		container.Features["ghcr.io/devcontainers/features/node:1"] = map[string]string{"version": g.NodeVersion}
+	case projects.HasProjectType(g.Projects, projects.GoLang):
+		container.Image = fmt.Sprintf("mcr.microsoft.com/devcontainers/go:1-%s-bookworm", g.GoVersion)
+	default:
+		container.Image = fmt.Sprintf("mcr.microsoft.com/devcontainers/typescript-node:1-%s-bookworm", g.NodeVersion)
+	}
+
+	if g.DockerInDocker {
+		container.Features["ghcr.io/devcontainers/features/docker-in-docker:2"] = map[string]string{}
+	}
+
+	container.Customizations.VSCode.Extensions = g.Extensions()
+	return container
+}
+
+// LaunchConfigurations returns the debug configurations: the Go program of Go
+// projects, the development script of Node.js projects and a browser for frontends
+func (g *Generator) LaunchConfigurations() []LaunchConfiguration {
+	configurations := []LaunchConfiguration{}
+	for _, project := range g.Projects {
+		folder := g.workspacePath(project)
+		suffix := g.nameSuffix(project)
+
+		if project.Type == projects.GoLang {
+			configuration := LaunchConfiguration{
+				Name:    "Launch" + suffix,
+				Type:    "go",
+				Request: "launch",
+				Mode:    "auto",
+				Program: folder + "/cmd",
+				Cwd:     folder,
+			}
+			if _, err := os.Stat(filepath.Join(project.Dir, ".env")); err == nil {
+				configuration.EnvFile = folder + "/.env"
+			}
+			configurations = append(configurations, configuration)
+			continue
+		}
+
+		pm := project.PackageManager.OrDefault()
+		if script := devScript(project); script != "" {
+			configurations = append(configurations, LaunchConfiguration{
+				Name:              "Launch" + suffix,
+				Type:              "node",
+				Request:           "launch",
+				RuntimeExecutable: string(pm),
+				RuntimeArgs:       []string{"run", script},
+				Cwd:               folder,
+				Console:           "integratedTerminal",
+				SkipFiles:         []string{"<node_internals>/**"},
+			})
+		}
+		if project.NodeType == projects.NextJS || project.NodeType.IsVite() {
+			configurations = append(configurations, LaunchConfiguration{
+				Name:    "Open in Chrome" + suffix,
+				Type:    "chrome",
+				Request: "launch",
+				URL:     fmt.Sprintf("http://localhost:%d", projects.FrontendPort(project.NodeType)),
+				WebRoot: folder,
+			})
+		}
+	}
+	return configurations
+}
+
+// Tasks returns the build and test tasks of every project
+func (g *Generator) Tasks() []Task {
+	tasks := []Task{}
+	for _, project := range g.Projects {
+		folder := g.workspacePath(project)
+		suffix := g.nameSuffix(project)
+
+		var build, test string
+		if project.Type == projects.GoLang {
+			build, test = "go build ./...", "go test ./..."
+		} else {
+			pm := project.PackageManager.OrDefault()
+			if project.HasScript("build") {
+				build = pm.Run("build")
+			}
+			if project.HasScript("test") {
+				test = pm.Run("test")
+			}
+		}
+
+		matcher := []string{}
+		if project.Type == projects.GoLang {
+			matcher = []string{"$go"}
+		}
+
+		if build != "" {
+			tasks = append(tasks, Task{
+				Label:          "build" + suffix,
+				Type:           "shell",
+				Command:        build,
+				Options:        &TaskOptions{Cwd: folder},
+				Group:          &TaskGroup{Kind: "build", IsDefault: len(g.Projects) == 1},
+				ProblemMatcher: matcher,
+			})
+		}
+		if test != "" {
+			tasks = append(tasks, Task{
+				Label:          "test" + suffix,
+				Type:           "shell",
+				Command:        test,
+				Options:        &TaskOptions{Cwd: folder},
+				Group:          &TaskGroup{Kind: "test", IsDefault: len(g.Projects) == 1},
+				ProblemMatcher: matcher,
+			})
+		}
+	}
+	return tasks
+}
+
+// Extensions returns the recommended VS Code extensions for the project types,
+// their frameworks and the generated Docker files
+func (g *Generator) Extensions() []string {
+	selected := map[string]bool{}
+	for _, project := range g.Projects {
+		if project.Type == projects.GoLang {
+			selected["golang.go"] = true
+			continue
+		}
+
+		selected["dbaeumer.vscode-eslint"] = true
+		selected["esbenp.prettier-vscode"] = true
+		switch project.NodeType {
+		case projects.ViteVue:
+			selected["vue.volar"] = true
+		case projects.ViteSvelte:
+			selected["svelte.svelte-vscode"] = true
+		}
+		if project.HasDependency("tailwindcss") {
+			selected["bradlc.vscode-tailwindcss"] = true
+		}
+		if _, err := os.Stat(filepath.Join(project.Dir, "Dockerfile")); err == nil {
+			selected["ms-azuretools.vscode-docker"] = true
+		}
+	}
+	if g.DockerInDocker {
+		selected["ms-azuretools.vscode-docker"] = true
+	}
+
+	extensions := make([]string, 0, len(selected))
+	for extension := range selected {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// workspacePath returns the project directory as a VS Code variable path
+func (g *Generator) workspacePath(project *projects.ProjectInfo) string {
+	if rel := project.RelativePath(g.Dir); rel != "." {
+		return "${workspaceFolder}/" + rel
+	}
+	return "${workspaceFolder}"
+}
+
+// nameSuffix distinguishes launch configurations and tasks of full-stack projects
+func (g *Generator) nameSuffix(project *projects.ProjectInfo) string {
+	if rel := project.RelativePath(g.Dir); rel != "." {
+		return " (" + rel + ")"
+	}
+	return ""
+}
+
+// devScript returns the package.json script that runs the project for debugging
+func devScript(project *projects.ProjectInfo) string {
+	for _, script := range []string{"start:debug", "dev", "start:dev", "start"} {
+		if project.HasScript(script) {
+			return script
+		}
+	}
+	return ""
+}
+
+// devPort returns the port the project listens on during development
+func devPort(project *projects.ProjectInfo) int {
+	if project.Type == projects.NodeJS && (project.NodeType == projects.NextJS || project.NodeType.IsVite()) {
+		return projects.FrontendPort(project.NodeType)
+	}
+	return docker.DefaultPort(project)
+}
+
+// encode renders a document as indented JSON without escaping HTML characters
+func encode(document interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,126 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+)
+
+func TestGenerate_GoProject(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/api\n\ngo 1.22.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte("services: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator(dir, []*projects.ProjectInfo{project}, false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var container DevContainer
+	readJSON(t, filepath.Join(dir, ".devcontainer", "devcontainer.json"), &container)
+	if container.Image != "mcr.microsoft.com/devcontainers/go:1-1.22-bookworm" {
+		t.Errorf("expected the Go 1.22 image, got %s", container.Image)
+	}
+	if _, ok := container.Features["ghcr.io/devcontainers/features/docker-in-docker:2"]; !ok {
+		t.Errorf("expected Docker-in-Docker with compose.yaml, got %v", container.Features)
+	}
+	if !reflect.DeepEqual(container.ForwardPorts, []int{8080}) {
+		t.Errorf("expected port 8080 to be forwarded, got %v", container.ForwardPorts)
+	}
+
+	var launch launchFile
+	readJSON(t, filepath.Join(dir, ".vscode", "launch.json"), &launch)
+	if len(launch.Configurations) != 1 || launch.Configurations[0].Program != "${workspaceFolder}/cmd" {
+		t.Errorf("expected a Go launch configuration for ./cmd, got %+v", launch.Configurations)
+	}
+
+	var extensions extensionsFile
+	readJSON(t, filepath.Join(dir, ".vscode", "extensions.json"), &extensions)
+	if !reflect.DeepEqual(extensions.Recommendations, []string{"golang.go", "ms-azuretools.vscode-docker"}) {
+		t.Errorf("unexpected extensions: %v", extensions.Recommendations)
+	}
+
+	if err := generator.Generate(); err == nil {
+		t.Error("expected an error when the files already exist")
+	}
+}
+
+func TestFiles_FullStackProject(t *testing.T) {
+	dir := t.TempDir()
+	api := filepath.Join(dir, "api")
+	web := filepath.Join(dir, "web")
+	for _, d := range []string{api, web} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkgJSON := `{"name": "web", "scripts": {"dev": "vite", "build": "vite build"}, "devDependencies": {"vite": "^6.0.0", "vue": "^3.5.0"}}`
+	if err := os.WriteFile(filepath.Join(web, "package.json"), []byte(pkgJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(web, "pnpm-lock.yaml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	frontend, err := projects.DetectProject(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &projects.ProjectInfo{Dir: api, Name: "shop/api", Type: projects.GoLang, GoType: projects.WebGo}
+
+	generator := NewGenerator(dir, []*projects.ProjectInfo{backend, frontend}, false)
+	generator.NodeVersion = "20"
+
+	container := generator.DevContainer()
+	if container.Features["ghcr.io/devcontainers/features/node:1"]["version"] != "20" {
+		t.Errorf("expected the Node.js 20 feature, got %v", container.Features)
+	}
+	wantPostCreate := "(cd api && go mod download) && (cd web && npm install -g pnpm && pnpm install)"
+	if container.PostCreateCommand != wantPostCreate {
+		t.Errorf("expected postCreateCommand %q, got %q", wantPostCreate, container.PostCreateCommand)
+	}
+	if !reflect.DeepEqual(container.ForwardPorts, []int{8080, 5173}) {
+		t.Errorf("expected ports 8080 and 5173, got %v", container.ForwardPorts)
+	}
+
+	var names []string
+	for _, configuration := range generator.LaunchConfigurations() {
+		names = append(names, configuration.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"Launch (api)", "Launch (web)", "Open in Chrome (web)"}) {
+		t.Errorf("unexpected launch configurations: %v", names)
+	}
+
+	var labels []string
+	for _, task := range generator.Tasks() {
+		labels = append(labels, task.Label+": "+task.Command)
+	}
+	want := []string{"build (api): go build ./...", "test (api): go test ./...", "build (web): pnpm run build"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("expected tasks %v, got %v", want, labels)
+	}
+}
+
+// readJSON decodes the JSON file at path into v
+func readJSON(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		t.Fatalf("%s is not valid JSON: %v", path, err)
+	}
+}
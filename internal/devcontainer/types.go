@@ -0,0 +1,70 @@
+package devcontainer
+
+// DevContainer is the content of .devcontainer/devcontainer.json
+type DevContainer struct {
+	Name              string                       `json:"name"`
+	Image             string                       `json:"image"`
+	Features          map[string]map[string]string `json:"features,omitempty"`
+	ForwardPorts      []int                        `json:"forwardPorts,omitempty"`
+	PostCreateCommand string                       `json:"postCreateCommand,omitempty"`
+	Customizations    struct {
+		VSCode struct {
+			Extensions []string `json:"extensions"`
+		} `json:"vscode"`
+	} `json:"customizations"`
+}
+
+// LaunchConfiguration is a debug configuration of .vscode/launch.json
+type LaunchConfiguration struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Request           string   `json:"request"`
+	Mode              string   `json:"mode,omitempty"`
+	Program           string   `json:"program,omitempty"`
+	RuntimeExecutable string   `json:"runtimeExecutable,omitempty"`
+	RuntimeArgs       []string `json:"runtimeArgs,omitempty"`
+	URL               string   `json:"url,omitempty"`
+	WebRoot           string   `json:"webRoot,omitempty"`
+	Cwd               string   `json:"cwd,omitempty"`
+	EnvFile           string   `json:"envFile,omitempty"`
+	Console           string   `json:"console,omitempty"`
+	SkipFiles         []string `json:"skipFiles,omitempty"`
+}
+
+// Task is a task of .vscode/tasks.json
+type Task struct {
+	Label          string       `json:"label"`
+	Type           string       `json:"type"`
+	Command        string       `json:"command"`
+	Options        *TaskOptions `json:"options,omitempty"`
+	Group          *TaskGroup   `json:"group,omitempty"`
+	ProblemMatcher []string     `json:"problemMatcher"`
+}
+
+// TaskOptions holds the working directory of a task
+type TaskOptions struct {
+	Cwd string `json:"cwd"`
+}
+
+// TaskGroup places a task in the build or test group
+type TaskGroup struct {
+	Kind      string `json:"kind"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// launchFile is the content of .vscode/launch.json
+type launchFile struct {
+	Version        string                `json:"version"`
+	Configurations []LaunchConfiguration `json:"configurations"`
+}
+
+// tasksFile is the content of .vscode/tasks.json
+type tasksFile struct {
+	Version string `json:"version"`
+	Tasks   []Task `json:"tasks"`
+}
+
+// extensionsFile is the content of .vscode/extensions.json
+type extensionsFile struct {
+	Recommendations []string `json:"recommendations"`
+}
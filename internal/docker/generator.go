@@ -0,0 +1,263 @@
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// Generator generates a Dockerfile and .dockerignore tailored to a project
+type Generator struct {
+	Project   *projects.ProjectInfo
+	ImageName string
+	// Port is the port the application listens on, 0 when it serves no traffic
+	Port int
+	// Force overwrites an existing Dockerfile and .dockerignore
+	Force bool
+	// Warnings lists steps the user has to complete by hand
+	Warnings []string
+}
+
+// NewGenerator creates a new Generator for the project. When imageName is empty
+// the image is named after the project directory. The port defaults to the one
+// used by the project template.
+func NewGenerator(project *projects.ProjectInfo, imageName string, force bool) *Generator {
+	if imageName == "" {
+		imageName = DefaultImageName(project.Dir)
+	}
+	return &Generator{
+		Project:   project,
+		ImageName: imageName,
+		Port:      DefaultPort(project),
+		Force:     force,
+	}
+}
+
+// packageManagerConfig describes how a package manager is used inside the image
+type packageManagerConfig struct {
+	BaseImage   string
+	Corepack    bool
+	Lockfile    string
+	Install     string
+	InstallProd string
+}
+
+// packageManagerSettings returns the Docker build settings of the package manager,
+// copying the lockfile found in dir
+func packageManagerSettings(pm projects.PackageManager, dir string) packageManagerConfig {
+	config := packageManagerConfig{
+		BaseImage:   "node:22-alpine",
+		Corepack:    pm == projects.PNPM || pm == projects.Yarn,
+		Lockfile:    pm.LockfileIn(dir),
+		Install:     strings.Join(pm.InstallFrozen().Args, " "),
+		InstallProd: strings.Join(pm.InstallProduction().Args, " "),
+	}
+	if pm == projects.Bun {
+		// The Bun image ships without npm, every command runs through bun
+		config.BaseImage = "oven/bun:1-alpine"
+	}
+	return config
+}
+
+// templateData holds the values rendered into the Docker templates
+type templateData struct {
+	packageManagerConfig
+	ImageName string
+	Port      int
+	Build     string
+	Artifacts []string
+	Command   string
+}
+
+// Generate writes the Dockerfile and .dockerignore into the project directory.
+// It refuses to overwrite existing files unless Force is set.
+func (g *Generator) Generate() error {
+	files, err := g.Files()
+	if err != nil {
+		return err
+	}
+
+	if !g.Force {
+		for name := range files {
+			if _, err := os.Stat(filepath.Join(g.Project.Dir, name)); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(g.Project.Dir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if g.Project.Type == projects.NodeJS && g.Project.NodeType == projects.NextJS {
+		return g.enableNextStandalone()
+	}
+	return nil
+}
+
+// Files renders the files to generate, keyed by their name in the project directory
+func (g *Generator) Files() (map[string]string, error) {
+	switch g.Project.Type {
+	case projects.GoLang:
+		return g.render(map[string]string{
+			"Dockerfile":    goDockerfileTemplate,
+			".dockerignore": goDockerignoreTemplate,
+		}, templateData{ImageName: g.ImageName, Port: g.Port})
+	case projects.NodeJS:
+		return g.nodeFiles()
+	default:
+		return nil, fmt.Errorf("unsupported project type: %s", g.Project.Type)
+	}
+}
+
+// nodeFiles selects the Dockerfile variant of a Node.js project
+func (g *Generator) nodeFiles() (map[string]string, error) {
+	pm := g.Project.PackageManager.OrDefault()
+
+	data := templateData{
+		packageManagerConfig: packageManagerSettings(pm, g.Project.Dir),
+		ImageName:            g.ImageName,
+		Port:                 g.Port,
+		Build:                pm.Run("build"),
+		Artifacts:            []string{"dist"},
+		Command:              `["node", "dist/index.js"]`,
+	}
+
+	templates := map[string]string{
+		"Dockerfile":    nodeDockerfileTemplate,
+		".dockerignore": nodeDockerignoreTemplate,
+	}
+
+	switch nodeType := g.Project.NodeType; {
+	case nodeType == projects.NextJS:
+		templates["Dockerfile"] = nextDockerfileTemplate
+	case nodeType == projects.NestJS:
+		data.Command = `["node", "dist/main"]`
+	case nodeType == projects.Remix:
+		data.Artifacts = []string{"build", "public"}
+		// The runtime stage has node only, whatever package manager built the app
+		data.Command = `["node_modules/.bin/remix-serve", "./build/server/index.js"]`
+	case nodeType.IsVite():
+		templates["Dockerfile"] = viteDockerfileTemplate
+		templates["nginx.conf"] = viteNginxTemplate
+	}
+
+	return g.render(templates, data)
+}
+
+// render executes every template with the given data
+func (g *Generator) render(templates map[string]string, data templateData) (map[string]string, error) {
+	files := make(map[string]string, len(templates))
+	for name, content := range templates {
+		tmpl, err := template.New(name).Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %v", name, err)
+		}
+		files[name] = buf.String()
+	}
+	return files, nil
+}
+
+// enableNextStandalone switches next.config.ts to the standalone output the Dockerfile copies
+func (g *Generator) enableNextStandalone() error {
+	configPath := filepath.Join(g.Project.Dir, "next.config.ts")
+	manual := "set `output: \"standalone\"` in your Next.js config"
+
+	if _, err := os.Stat(configPath); err != nil {
+		g.Warnings = append(g.Warnings, manual)
+		return nil
+	}
+
+	found, err := utils.InsertBeforeLine(configPath, "};", `  output: "standalone",`)
+	if err != nil {
+		return err
+	}
+	if !found {
+		g.Warnings = append(g.Warnings, manual)
+	}
+	return nil
+}
+
+// DefaultPort returns the port the project template listens on, 0 when it serves no traffic
+func DefaultPort(project *projects.ProjectInfo) int {
+	switch project.Type {
+	case projects.GoLang:
+		if project.GoType == projects.WebGo {
+			return projects.APIPort
+		}
+		return 0
+	case projects.NodeJS:
+		switch {
+		case project.NodeType == projects.TypeScriptBasic:
+			return 0
+		case project.NodeType.IsVite():
+			// nginx-unprivileged cannot bind to ports below 1024
+			return 8080
+		default:
+			return 3000
+		}
+	}
+	return 0
+}
+
+// DefaultUser returns the numeric user the generated image runs as, which Kubernetes
+// needs to enforce runAsNonRoot on images naming their user
+func DefaultUser(project *projects.ProjectInfo) int {
+	switch {
+	case project.Type == projects.NodeJS && project.NodeType.IsVite():
+		// nginx in nginx-unprivileged
+		return 101
+	case project.Type == projects.NodeJS:
+		// node in node:alpine
+		return 1000
+	default:
+		// nonroot in distroless
+		return 65532
+	}
+}
+
+// invalidImageChars matches the characters not allowed in a Docker image name
+var invalidImageChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// DefaultImageName derives a valid image name from the project directory
+func DefaultImageName(dir string) string {
+	name := strings.ToLower(filepath.Base(dir))
+	name = invalidImageChars.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-._")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// imageTitle matches the title label written into the generated Dockerfiles
+var imageTitle = regexp.MustCompile(`^LABEL\s.*\borg\.opencontainers\.image\.title="?([^"\s]+)"?`)
+
+// ImageTitle returns the title label of the Dockerfile in dir, empty when there is
+// no Dockerfile or it has no such label
+func ImageTitle(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "Dockerfile"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if match := imageTitle.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/testutil"
+)
+
+func TestGenerate_GoWeb(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "My_API")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator(project, "", false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "Dockerfile"),
+		"FROM gcr.io/distroless/static-debian12:nonroot",
+		`LABEL org.opencontainers.image.title="my_api"`,
+		"EXPOSE 8080")
+	testutil.ExpectContains(t, filepath.Join(dir, ".dockerignore"), ".env")
+	if name := ImageTitle(dir); name != "my_api" {
+		t.Errorf("expected the image name from the Dockerfile label, got %q", name)
+	}
+
+	// An existing Dockerfile is only replaced with Force
+	if err := generator.Generate(); err == nil {
+		t.Fatal("expected an error when the Dockerfile already exists")
+	}
+	generator.Force = true
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error with force, got %v", err)
+	}
+}
+
+func TestGenerate_NextJS(t *testing.T) {
+	dir := t.TempDir()
+	config := "import type { NextConfig } from \"next\";\n\nconst nextConfig: NextConfig = {\n  /* config options here */\n};\n\nexport default nextConfig;\n"
+	if err := os.WriteFile(filepath.Join(dir, "next.config.ts"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "web", Type: projects.NodeJS, NodeType: projects.NextJS, PackageManager: projects.PNPM}
+	if err := NewGenerator(project, "shop-web", false).Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "Dockerfile"),
+		"RUN corepack enable",
+		"COPY package.json pnpm-lock.yaml ./",
+		"RUN pnpm install --frozen-lockfile",
+		"COPY --from=build --chown=node:node /app/.next/standalone ./",
+		"EXPOSE 3000")
+	testutil.ExpectContains(t, filepath.Join(dir, "next.config.ts"), "  output: \"standalone\",\n};")
+}
+
+func TestNodeFiles_Variants(t *testing.T) {
+	tests := []struct {
+		nodeType projects.NodeProjectType
+		snippets []string
+	}{
+		{projects.Express, []string{"RUN npm ci --omit=dev", `CMD ["node", "dist/index.js"]`, "EXPOSE 3000"}},
+		{projects.NestJS, []string{`CMD ["node", "dist/main"]`}},
+		{projects.ViteReact, []string{"FROM nginxinc/nginx-unprivileged:alpine", "EXPOSE 8080"}},
+	}
+
+	for _, tt := range tests {
+		project := &projects.ProjectInfo{Dir: t.TempDir(), Type: projects.NodeJS, NodeType: tt.nodeType}
+		files, err := NewGenerator(project, "app", false).Files()
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.nodeType, err)
+		}
+		for _, snippet := range tt.snippets {
+			if !strings.Contains(files["Dockerfile"], snippet) {
+				t.Errorf("%s: expected Dockerfile to contain %q, got:\n%s", tt.nodeType, snippet, files["Dockerfile"])
+			}
+		}
+	}
+}
+
+func TestNodeFiles_Bun(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bun.lockb"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Projects of Bun 1.1 and earlier keep the binary lockfile
+	project := &projects.ProjectInfo{Dir: dir, Type: projects.NodeJS, NodeType: projects.Remix, PackageManager: projects.Bun}
+	files, err := NewGenerator(project, "app", false).Files()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, snippet := range []string{
+		"FROM oven/bun:1-alpine AS base",
+		"COPY package.json bun.lockb ./",
+		"RUN bun install --frozen-lockfile --production",
+		"FROM node:22-alpine AS runtime",
+		`CMD ["node_modules/.bin/remix-serve", "./build/server/index.js"]`,
+	} {
+		if !strings.Contains(files["Dockerfile"], snippet) {
+			t.Errorf("expected Dockerfile to contain %q, got:\n%s", snippet, files["Dockerfile"])
+		}
+	}
+	if strings.Contains(files["Dockerfile"], "npm") {
+		t.Errorf("expected no npm command in the Bun image, got:\n%s", files["Dockerfile"])
+	}
+}
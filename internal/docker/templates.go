@@ -0,0 +1,176 @@
+package docker
+
+const goDockerfileTemplate = `# syntax=docker/dockerfile:1
+
+FROM golang:1-alpine AS build
+WORKDIR /src
+
+COPY go.mod go.sum* ./
+RUN go mod download
+
+COPY . .
+RUN CGO_ENABLED=0 GOOS=linux go build -trimpath -ldflags="-s -w" -o /out/app ./cmd
+
+FROM gcr.io/distroless/static-debian12:nonroot
+LABEL org.opencontainers.image.title="{{.ImageName}}"
+WORKDIR /app
+
+COPY --from=build /out/app /app/app
+{{- if .Port}}
+
+EXPOSE {{.Port}}
+{{- end}}
+
+USER nonroot:nonroot
+ENTRYPOINT ["/app/app"]
+`
+
+const nodeDockerfileTemplate = `# syntax=docker/dockerfile:1
+
+FROM {{.BaseImage}} AS base
+WORKDIR /app
+{{- if .Corepack}}
+RUN corepack enable
+{{- end}}
+
+FROM base AS deps
+COPY package.json {{.Lockfile}} ./
+RUN {{.Install}}
+
+FROM base AS build
+COPY --from=deps /app/node_modules ./node_modules
+COPY . .
+RUN {{.Build}}
+
+FROM base AS prod-deps
+COPY package.json {{.Lockfile}} ./
+RUN {{.InstallProd}}
+
+FROM node:22-alpine AS runtime
+LABEL org.opencontainers.image.title="{{.ImageName}}"
+WORKDIR /app
+ENV NODE_ENV=production
+{{- if .Port}}
+ENV PORT={{.Port}}
+{{- end}}
+
+COPY --from=prod-deps /app/node_modules ./node_modules
+COPY --from=build /app/package.json ./package.json
+{{- range .Artifacts}}
+COPY --from=build /app/{{.}} ./{{.}}
+{{- end}}
+{{- if .Port}}
+
+EXPOSE {{.Port}}
+{{- end}}
+
+USER node
+CMD {{.Command}}
+`
+
+const nextDockerfileTemplate = `# syntax=docker/dockerfile:1
+
+FROM {{.BaseImage}} AS base
+WORKDIR /app
+{{- if .Corepack}}
+RUN corepack enable
+{{- end}}
+
+FROM base AS deps
+COPY package.json {{.Lockfile}} ./
+RUN {{.Install}}
+
+FROM base AS build
+COPY --from=deps /app/node_modules ./node_modules
+COPY . .
+ENV NEXT_TELEMETRY_DISABLED=1
+RUN {{.Build}}
+
+FROM node:22-alpine AS runtime
+LABEL org.opencontainers.image.title="{{.ImageName}}"
+WORKDIR /app
+ENV NODE_ENV=production
+ENV NEXT_TELEMETRY_DISABLED=1
+ENV PORT={{.Port}}
+ENV HOSTNAME=0.0.0.0
+
+# Next.js standalone output bundles the server with only the dependencies it needs
+COPY --from=build --chown=node:node /app/.next/standalone ./
+COPY --from=build --chown=node:node /app/.next/static ./.next/static
+COPY --from=build --chown=node:node /app/public ./public
+
+EXPOSE {{.Port}}
+
+USER node
+CMD ["node", "server.js"]
+`
+
+const viteDockerfileTemplate = `# syntax=docker/dockerfile:1
+
+FROM {{.BaseImage}} AS build
+WORKDIR /app
+{{- if .Corepack}}
+RUN corepack enable
+{{- end}}
+
+COPY package.json {{.Lockfile}} ./
+RUN {{.Install}}
+
+COPY . .
+RUN {{.Build}}
+
+FROM nginxinc/nginx-unprivileged:alpine
+LABEL org.opencontainers.image.title="{{.ImageName}}"
+
+COPY nginx.conf /etc/nginx/conf.d/default.conf
+COPY --from=build /app/dist /usr/share/nginx/html
+
+EXPOSE {{.Port}}
+`
+
+// viteNginxTemplate serves the single page application and falls back to
+// index.html so client side routes survive a page reload.
+const viteNginxTemplate = `server {
+    listen {{.Port}};
+    root /usr/share/nginx/html;
+    index index.html;
+
+    location / {
+        try_files $uri $uri/ /index.html;
+    }
+
+    location /assets/ {
+        expires 1y;
+        add_header Cache-Control "public, immutable";
+    }
+}
+`
+
+const goDockerignoreTemplate = `.git
+.gitignore
+.env
+*.env
+bin/
+tmp/
+coverage.out
+*.test
+Dockerfile
+.dockerignore
+k8s/
+`
+
+const nodeDockerignoreTemplate = `.git
+.gitignore
+.env
+*.env
+node_modules
+dist
+build
+.next
+coverage
+npm-debug.log*
+yarn-error.log*
+Dockerfile
+.dockerignore
+k8s/
+`
@@ -0,0 +1,167 @@
+package gitignore
+
+// Fragment is a named group of .gitignore entries for a language or tool
+type Fragment struct {
+	Name        string
+	Title       string
+	Description string
+	Entries     []string
+}
+
+// fragments holds every fragment keyed by name
+var fragments = map[string]Fragment{
+	"go": {
+		Name:        "go",
+		Title:       "Go",
+		Description: "Binaries, test binaries and coverage profiles",
+		Entries: []string{
+			"bin/",
+			"*.exe",
+			"*.exe~",
+			"*.dll",
+			"*.so",
+			"*.dylib",
+			"*.test",
+			"*.out",
+			"coverage.out",
+			"coverage.html",
+			"go.work",
+			"go.work.sum",
+		},
+	},
+	"node": {
+		Name:        "node",
+		Title:       "Node.js",
+		Description: "Dependencies, build output and package manager logs",
+		Entries: []string{
+			"node_modules/",
+			"dist/",
+			"build/",
+			".next/",
+			"out/",
+			".turbo/",
+			".cache/",
+			"coverage/",
+			"*.tsbuildinfo",
+			"next-env.d.ts",
+			"npm-debug.log*",
+			"yarn-debug.log*",
+			"yarn-error.log*",
+			"pnpm-debug.log*",
+			".pnpm-store/",
+			".yarn/*",
+			"!.yarn/releases",
+			"!.yarn/plugins",
+		},
+	},
+	"python": {
+		Name:        "python",
+		Title:       "Python",
+		Description: "Bytecode, virtual environments and tool caches",
+		Entries: []string{
+			"__pycache__/",
+			"*.py[cod]",
+			"*.egg-info/",
+			".venv/",
+			"venv/",
+			".pytest_cache/",
+			".mypy_cache/",
+			".ruff_cache/",
+			".coverage",
+			"htmlcov/",
+		},
+	},
+	"rust": {
+		Name:        "rust",
+		Title:       "Rust",
+		Description: "Cargo build output",
+		Entries: []string{
+			"target/",
+			"**/*.rs.bk",
+			"*.pdb",
+		},
+	},
+	"env": {
+		Name:        "env",
+		Title:       "Environment",
+		Description: "Local environment files holding secrets",
+		Entries: []string{
+			".env",
+			".env.local",
+			".env.*.local",
+		},
+	},
+	"ide": {
+		Name:        "ide",
+		Title:       "IDE",
+		Description: "JetBrains, VS Code and Vim files",
+		Entries: []string{
+			".idea/",
+			".vscode/*",
+			"!.vscode/settings.json",
+			"!.vscode/tasks.json",
+			"!.vscode/launch.json",
+			"!.vscode/extensions.json",
+			"*.swp",
+			"*.swo",
+			"*~",
+		},
+	},
+	"os": {
+		Name:        "os",
+		Title:       "OS",
+		Description: "macOS, Windows and Linux metadata files",
+		Entries: []string{
+			".DS_Store",
+			"._*",
+			"Thumbs.db",
+			"ehthumbs.db",
+			"Desktop.ini",
+			".directory",
+			".Trash-*",
+		},
+	},
+	"docker": {
+		Name:        "docker",
+		Title:       "Docker",
+		Description: "Local Compose overrides",
+		Entries: []string{
+			"compose.override.yaml",
+			"docker-compose.override.yml",
+		},
+	},
+	"terraform": {
+		Name:        "terraform",
+		Title:       "Terraform",
+		Description: "Providers, state files and local variables",
+		Entries: []string{
+			".terraform/",
+			"*.tfstate",
+			"*.tfstate.*",
+			"crash.log",
+			"crash.*.log",
+			"*.tfvars",
+			"*.tfvars.json",
+			"override.tf",
+			"override.tf.json",
+			"*_override.tf",
+			"*_override.tf.json",
+			".terraformrc",
+			"terraform.rc",
+		},
+	},
+}
+
+// order is the order fragments are written in
+var order = []string{"env", "go", "node", "python", "rust", "docker", "terraform", "ide", "os"}
+
+// Names returns the names of every fragment
+func Names() []string {
+	return append([]string{}, order...)
+}
+
+// Get returns the fragment with the given name
+func Get(name string) (Fragment, bool) {
+	fragment, ok := fragments[name]
+	return fragment, ok
+}
@@ -0,0 +1,126 @@
+package gitignore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Validate checks that every fragment name exists
+func Validate(names []string) error {
+	for _, name := range names {
+		if _, ok := fragments[name]; !ok {
+			return fmt.Errorf("unknown fragment %q, available fragments: %s", name, strings.Join(order, ", "))
+		}
+	}
+	return nil
+}
+
+// Add appends the fragments to the .gitignore file at path, creating it when needed.
+// Entries already present in the file, or added by an earlier fragment, are skipped
+// and fragments without new entries are left out entirely.
+//
+// Parameters:
+//   - path: The .gitignore file to extend
+//   - names: The fragment names, written in the order given
+//
+// Returns:
+//   - []string: The entries that were added
+//   - error: An error if a fragment is unknown or the file cannot be written
+func Add(path string, names []string) ([]string, error) {
+	if err := Validate(names); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	present := map[string]bool{}
+	for _, line := range strings.Split(string(content), "\n") {
+		present[normalize(line)] = true
+	}
+
+	var added []string
+	var sections []string
+	for _, name := range names {
+		fragment := fragments[name]
+
+		var entries []string
+		for _, entry := range fragment.Entries {
+			if present[normalize(entry)] {
+				continue
+			}
+			present[normalize(entry)] = true
+			entries = append(entries, entry)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		added = append(added, entries...)
+		sections = append(sections, "# "+fragment.Title+"\n"+strings.Join(entries, "\n")+"\n")
+	}
+	if len(sections) == 0 {
+		return nil, nil
+	}
+
+	var out strings.Builder
+	out.Write(content)
+	if len(content) > 0 {
+		if !strings.HasSuffix(string(content), "\n") {
+			out.WriteString("\n")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(strings.Join(sections, "\n"))
+
+	if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return added, nil
+}
+
+// normalize makes equivalent entries compare equal, e.g. "/node_modules" and "node_modules/"
+func normalize(entry string) string {
+	entry = strings.TrimSpace(entry)
+	if strings.HasPrefix(entry, "!") {
+		return "!" + normalize(entry[1:])
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(entry, "/"), "/")
+}
+
+// ForDir chooses the fragments matching the project at dir: the languages found in
+// the directory and in the api/ and web/ directories of full-stack projects, the tools
+// whose files are present, and the environment, IDE and OS fragments.
+func ForDir(dir string) []string {
+	markers := map[string][]string{
+		"go":        {"go.mod"},
+		"node":      {"package.json"},
+		"python":    {"pyproject.toml", "requirements.txt", "setup.py"},
+		"rust":      {"Cargo.toml"},
+		"docker":    {"Dockerfile", "compose.yaml", "docker-compose.yml"},
+		"terraform": {"*.tf"},
+	}
+
+	selected := map[string]bool{"env": true, "ide": true, "os": true}
+	for _, base := range []string{dir, filepath.Join(dir, "api"), filepath.Join(dir, "web")} {
+		for name, patterns := range markers {
+			for _, pattern := range patterns {
+				if matches, _ := filepath.Glob(filepath.Join(base, pattern)); len(matches) > 0 {
+					selected[name] = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for _, name := range order {
+		if selected[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
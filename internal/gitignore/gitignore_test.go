@@ -0,0 +1,71 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAdd_SkipsExistingEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gitignore")
+	existing := "/node_modules\n.env\n"
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := Add(path, []string{"env", "node"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, entry := range added {
+		if entry == "node_modules/" || entry == ".env" {
+			t.Errorf("expected %s to be skipped", entry)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), existing+"\n# Environment\n.env.local\n") {
+		t.Errorf("unexpected .gitignore:\n%s", content)
+	}
+	if !strings.Contains(string(content), "\n# Node.js\ndist/\n") {
+		t.Errorf("expected a Node.js section, got:\n%s", content)
+	}
+
+	// Adding the same fragments again is a no-op
+	added, err = Add(path, []string{"node", "env"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(added) != 0 {
+		t.Errorf("expected no new entries, got %v", added)
+	}
+}
+
+func TestAdd_UnknownFragment(t *testing.T) {
+	if _, err := Add(filepath.Join(t.TempDir(), ".gitignore"), []string{"cobol"}); err == nil {
+		t.Fatal("expected an error for an unknown fragment")
+	}
+}
+
+func TestForDir(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"api/go.mod", "web/package.json", "compose.yaml", "infra.tf"} {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{"env", "go", "node", "docker", "terraform", "ide", "os"}
+	if names := ForDir(dir); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
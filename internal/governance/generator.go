@@ -0,0 +1,345 @@
+package governance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/ci"
+	"github.com/moabdelazem/initiator/internal/projects"
+)
+
+// Bot is the service keeping the dependencies up to date
+type Bot string
+
+const (
+	Dependabot Bot = "dependabot"
+	Renovate   Bot = "renovate"
+)
+
+// ParseBot validates a dependency update bot name
+func ParseBot(name string) (Bot, error) {
+	switch bot := Bot(strings.ToLower(name)); bot {
+	case Dependabot, Renovate:
+		return bot, nil
+	default:
+		return "", fmt.Errorf("unsupported dependency update bot %q, use %s or %s", name, Dependabot, Renovate)
+	}
+}
+
+// ValidateOwner checks that a code owner is a user or team handle like @org/team,
+// or an email address
+func ValidateOwner(owner string) error {
+	if strings.HasPrefix(owner, "@") && len(owner) > 1 && !strings.ContainsAny(owner, " \t") {
+		return nil
+	}
+	if at := strings.Index(owner, "@"); at > 0 && at < len(owner)-1 && !strings.ContainsAny(owner, " \t") {
+		return nil
+	}
+	return fmt.Errorf("invalid code owner %q, use a handle like @org/team or an email address", owner)
+}
+
+// Ecosystem is a package ecosystem watched by the dependency update bot
+type Ecosystem struct {
+	// Name identifies the group of minor and patch updates
+	Name string
+	// Dependabot is the Dependabot package-ecosystem
+	Dependabot string
+	// Renovate is the Renovate manager
+	Renovate string
+	// Directory is the manifest directory relative to the repository, starting with /
+	Directory string
+}
+
+// Generator generates the governance files of a repository: code owners, issue and
+// pull request templates, contribution and security guides and the dependency
+// update bot configuration.
+type Generator struct {
+	Dir      string
+	Projects []*projects.ProjectInfo
+	Provider ci.Provider
+	Bot      Bot
+	// Owners are the code owners of every file, CODEOWNERS is skipped when empty
+	Owners []string
+	// SecurityContact is where vulnerabilities are reported, the provider's private
+	// reporting is described when empty
+	SecurityContact string
+	// Force overwrites existing files
+	Force bool
+	// Warnings lists steps the user has to complete by hand
+	Warnings []string
+}
+
+// NewGenerator creates a new Generator for the projects of dir
+func NewGenerator(dir string, apps []*projects.ProjectInfo, provider ci.Provider, bot Bot, owners []string, force bool) *Generator {
+	return &Generator{
+		Dir:      dir,
+		Projects: apps,
+		Provider: provider,
+		Bot:      bot,
+		Owners:   owners,
+		Force:    force,
+	}
+}
+
+// Generate writes the files returned by Files into the repository.
+// It refuses to overwrite existing files unless Force is set.
+func (g *Generator) Generate() error {
+	if g.Provider == ci.GitLab && g.Bot == Dependabot {
+		return fmt.Errorf("%s is only available on GitHub, use %s for GitLab", Dependabot, Renovate)
+	}
+	for _, owner := range g.Owners {
+		if err := ValidateOwner(owner); err != nil {
+			return err
+		}
+	}
+
+	files, err := g.Files()
+	if err != nil {
+		return err
+	}
+
+	if !g.Force {
+		for name := range files {
+			if _, err := os.Stat(filepath.Join(g.Dir, name)); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	for name, content := range files {
+		target := filepath.Join(g.Dir, name)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", filepath.Dir(name), err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", name, err)
+		}
+	}
+
+	if len(g.Owners) == 0 {
+		g.Warnings = append(g.Warnings, "CODEOWNERS was skipped, pass --owners @org/team to generate it")
+	}
+	if g.Bot == Renovate {
+		g.Warnings = append(g.Warnings, "install the Renovate app for the repository, see https://docs.renovatebot.com/getting-started/installing-onboarding/")
+	}
+	return nil
+}
+
+// Files returns the generated files keyed by their path relative to the repository
+func (g *Generator) Files() (map[string]string, error) {
+	files := map[string]string{}
+
+	configDir := ".github"
+	if g.Provider == ci.GitLab {
+		configDir = ".gitlab"
+	}
+
+	templates := map[string]string{
+		"CONTRIBUTING.md": contributingTemplate,
+		"SECURITY.md":     securityTemplate,
+	}
+	if len(g.Owners) > 0 {
+		templates[filepath.Join(configDir, "CODEOWNERS")] = codeownersTemplate
+	}
+	if g.Provider == ci.GitLab {
+		templates[filepath.Join(configDir, "issue_templates", "Bug.md")] = gitlabBugReportTemplate
+		templates[filepath.Join(configDir, "issue_templates", "Feature.md")] = gitlabFeatureRequestTemplate
+		templates[filepath.Join(configDir, "merge_request_templates", "Default.md")] = pullRequestTemplate
+	} else {
+		templates[filepath.Join(configDir, "ISSUE_TEMPLATE", "bug_report.yml")] = githubBugReportTemplate
+		templates[filepath.Join(configDir, "ISSUE_TEMPLATE", "feature_request.yml")] = githubFeatureRequestTemplate
+		templates[filepath.Join(configDir, "pull_request_template.md")] = pullRequestTemplate
+	}
+	if g.Bot == Dependabot {
+		templates[filepath.Join(configDir, "dependabot.yml")] = dependabotTemplate
+	}
+
+	data := g.templateData()
+	for name, content := range templates {
+		tmpl, err := template.New(name).Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %v", name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %v", name, err)
+		}
+		files[name] = buf.String()
+	}
+
+	if g.Bot == Renovate {
+		renovate, err := g.renovateConfig()
+		if err != nil {
+			return nil, err
+		}
+		files["renovate.json"] = renovate
+	}
+
+	return files, nil
+}
+
+// projectData describes a project in CONTRIBUTING.md
+type projectData struct {
+	Path  string
+	Setup []string
+}
+
+// templateData returns the data the templates are rendered with
+func (g *Generator) templateData() interface{} {
+	data := struct {
+		Name                string
+		Provider            ci.Provider
+		Owners              []string
+		Contact             string
+		Projects            []projectData
+		Multiple            bool
+		Checks              []string
+		Hooks               string
+		ConventionalCommits bool
+		RequestName         string
+		Ecosystems          []Ecosystem
+	}{
+		Name:        filepath.Base(g.Dir),
+		Provider:    g.Provider,
+		Owners:      g.Owners,
+		Contact:     g.SecurityContact,
+		Multiple:    len(g.Projects) > 1,
+		RequestName: "pull request",
+		Ecosystems:  g.Ecosystems(),
+	}
+	if g.Provider == ci.GitLab {
+		data.RequestName = "merge request"
+	}
+
+	for _, project := range g.Projects {
+		path := project.RelativePath(g.Dir)
+		var setup, checks []string
+		if project.Type == projects.GoLang {
+			setup = []string{"go mod download"}
+			checks = []string{"go vet ./...", "go test ./..."}
+			if g.exists(project.Dir, ".golangci.yml") {
+				checks = append(checks, "golangci-lint run")
+			}
+		} else {
+			pm := project.PackageManager.OrDefault()
+			setup = []string{strings.Join(pm.Install().Args, " ")}
+			for _, script := range []string{"lint", "test", "build"} {
+				if project.HasScript(script) {
+					checks = append(checks, pm.Run(script))
+				}
+			}
+		}
+
+		data.Projects = append(data.Projects, projectData{Path: path, Setup: setup})
+		for _, check := range checks {
+			if path != "." {
+				check = "(cd " + path + " && " + check + ")"
+			}
+			data.Checks = append(data.Checks, check)
+		}
+	}
+
+	switch {
+	case g.exists(g.Dir, ".pre-commit-config.yaml"):
+		data.Hooks = "pre-commit"
+	case g.exists(g.Dir, "lefthook.yml"):
+		data.Hooks = "lefthook"
+	}
+	if hook, err := os.ReadFile(filepath.Join(g.Dir, ".git", "hooks", "commit-msg")); err == nil {
+		data.ConventionalCommits = strings.Contains(string(hook), "Conventional Commits")
+	}
+	return data
+}
+
+// Ecosystems returns the package ecosystems of the projects: Go modules, npm
+// packages and Docker base images, plus the CI actions on GitHub
+func (g *Generator) Ecosystems() []Ecosystem {
+	var ecosystems []Ecosystem
+	for _, project := range g.Projects {
+		directory := "/"
+		if path := project.RelativePath(g.Dir); path != "." {
+			directory = "/" + path
+		}
+
+		if project.Type == projects.GoLang {
+			ecosystems = append(ecosystems, Ecosystem{Name: "go", Dependabot: "gomod", Renovate: "gomod", Directory: directory})
+		} else if project.PackageManager == projects.Bun {
+			ecosystems = append(ecosystems, Ecosystem{Name: "bun", Dependabot: "bun", Renovate: "bun", Directory: directory})
+		} else {
+			ecosystems = append(ecosystems, Ecosystem{Name: "npm", Dependabot: "npm", Renovate: "npm", Directory: directory})
+		}
+		if g.exists(project.Dir, "Dockerfile") {
+			ecosystems = append(ecosystems, Ecosystem{Name: "docker", Dependabot: "docker", Renovate: "dockerfile", Directory: directory})
+		}
+	}
+
+	if g.Provider == ci.GitLab {
+		if g.exists(g.Dir, ".gitlab-ci.yml") {
+			ecosystems = append(ecosystems, Ecosystem{Name: "gitlab-ci", Renovate: "gitlabci", Directory: "/"})
+		}
+	} else {
+		ecosystems = append(ecosystems, Ecosystem{Name: "github-actions", Dependabot: "github-actions", Renovate: "github-actions", Directory: "/"})
+	}
+
+	// Group names have to be unique, suffix them with the directory when needed
+	seen := map[string]int{}
+	for _, ecosystem := range ecosystems {
+		seen[ecosystem.Name]++
+	}
+	for i, ecosystem := range ecosystems {
+		if seen[ecosystem.Name] > 1 && ecosystem.Directory != "/" {
+			ecosystems[i].Name += "-" + strings.TrimPrefix(ecosystem.Directory, "/")
+		}
+	}
+	return ecosystems
+}
+
+// renovateConfig returns renovate.json, enabling the managers of the ecosystems and
+// grouping minor and patch updates
+func (g *Generator) renovateConfig() (string, error) {
+	type packageRule struct {
+		MatchUpdateTypes []string `json:"matchUpdateTypes"`
+		GroupName        string   `json:"groupName"`
+	}
+	config := struct {
+		Schema            string        `json:"$schema"`
+		Extends           []string      `json:"extends"`
+		EnabledManagers   []string      `json:"enabledManagers"`
+		PostUpdateOptions []string      `json:"postUpdateOptions,omitempty"`
+		PackageRules      []packageRule `json:"packageRules"`
+	}{
+		Schema:  "https://docs.renovatebot.com/renovate-schema.json",
+		Extends: []string{"config:recommended"},
+		PackageRules: []packageRule{
+			{MatchUpdateTypes: []string{"minor", "patch"}, GroupName: "minor and patch updates"},
+		},
+	}
+
+	enabled := map[string]bool{}
+	for _, ecosystem := range g.Ecosystems() {
+		if !enabled[ecosystem.Renovate] {
+			enabled[ecosystem.Renovate] = true
+			config.EnabledManagers = append(config.EnabledManagers, ecosystem.Renovate)
+		}
+	}
+	if enabled["gomod"] {
+		config.PostUpdateOptions = []string{"gomodTidy"}
+	}
+
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render renovate.json: %v", err)
+	}
+	return string(content) + "\n", nil
+}
+
+// exists reports whether the file exists in dir
+func (g *Generator) exists(dir string, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
@@ -0,0 +1,178 @@
+package governance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/ci"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"gopkg.in/yaml.v3"
+)
+
+// fullStack creates a full-stack project with a Go API and a pnpm frontend
+func fullStack(t *testing.T) (string, []*projects.ProjectInfo) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "shop")
+	api := filepath.Join(dir, "api")
+	web := filepath.Join(dir, "web")
+	for _, d := range []string{api, web} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(api, "Dockerfile"):     "FROM scratch\n",
+		filepath.Join(web, "package.json"):   `{"name": "web", "scripts": {"lint": "next lint", "build": "next build"}, "dependencies": {"next": "15"}}`,
+		filepath.Join(web, "pnpm-lock.yaml"): "",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	frontend, err := projects.DetectProject(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &projects.ProjectInfo{Dir: api, Name: "shop/api", Type: projects.GoLang, GoType: projects.WebGo}
+	return dir, []*projects.ProjectInfo{backend, frontend}
+}
+
+func TestGenerate_GitHub(t *testing.T) {
+	dir, apps := fullStack(t)
+
+	generator := NewGenerator(dir, apps, ci.GitHub, Dependabot, []string{"@acme/platform", "ops@acme.dev"}, false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{
+		".github/ISSUE_TEMPLATE/bug_report.yml",
+		".github/ISSUE_TEMPLATE/feature_request.yml",
+		".github/pull_request_template.md",
+		"CONTRIBUTING.md",
+		"SECURITY.md",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+
+	codeowners, err := os.ReadFile(filepath.Join(dir, ".github", "CODEOWNERS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(codeowners), "\n* @acme/platform ops@acme.dev\n") {
+		t.Errorf("unexpected CODEOWNERS:\n%s", codeowners)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".github", "dependabot.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dependabot struct {
+		Updates []struct {
+			Ecosystem string `yaml:"package-ecosystem"`
+			Directory string `yaml:"directory"`
+		} `yaml:"updates"`
+	}
+	if err := yaml.Unmarshal(content, &dependabot); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+	var updates []string
+	for _, update := range dependabot.Updates {
+		updates = append(updates, update.Ecosystem+" "+update.Directory)
+	}
+	want := []string{"gomod /api", "docker /api", "npm /web", "github-actions /"}
+	if !reflect.DeepEqual(updates, want) {
+		t.Errorf("expected updates %v, got %v", want, updates)
+	}
+
+	contributing, err := os.ReadFile(filepath.Join(dir, "CONTRIBUTING.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, snippet := range []string{"# Contributing to shop", "### web\n\n```bash\ncd web\npnpm install\n```", "(cd api && go test ./...)", "(cd web && pnpm run lint)", "Submitting a pull request"} {
+		if !strings.Contains(string(contributing), snippet) {
+			t.Errorf("expected CONTRIBUTING.md to contain %q, got:\n%s", snippet, contributing)
+		}
+	}
+
+	for _, name := range []string{"bug_report.yml", "feature_request.yml"} {
+		content, err := os.ReadFile(filepath.Join(dir, ".github", "ISSUE_TEMPLATE", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var form map[string]interface{}
+		if err := yaml.Unmarshal(content, &form); err != nil {
+			t.Errorf("expected %s to be valid YAML, got %v", name, err)
+		}
+	}
+}
+
+func TestGenerate_GitLabRenovate(t *testing.T) {
+	dir, apps := fullStack(t)
+
+	if err := NewGenerator(dir, apps, ci.GitLab, Dependabot, nil, false).Generate(); err == nil {
+		t.Fatal("expected an error for Dependabot on GitLab")
+	}
+
+	generator := NewGenerator(dir, apps, ci.GitLab, Renovate, nil, false)
+	generator.SecurityContact = "security@acme.dev"
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{".gitlab/issue_templates/Bug.md", ".gitlab/merge_request_templates/Default.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gitlab", "CODEOWNERS")); err == nil {
+		t.Error("expected CODEOWNERS to be skipped without owners")
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "renovate.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var renovate struct {
+		EnabledManagers   []string `json:"enabledManagers"`
+		PostUpdateOptions []string `json:"postUpdateOptions"`
+	}
+	if err := json.Unmarshal(content, &renovate); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if !reflect.DeepEqual(renovate.EnabledManagers, []string{"gomod", "dockerfile", "npm"}) {
+		t.Errorf("unexpected managers: %v", renovate.EnabledManagers)
+	}
+	if !reflect.DeepEqual(renovate.PostUpdateOptions, []string{"gomodTidy"}) {
+		t.Errorf("expected go mod tidy after updates, got %v", renovate.PostUpdateOptions)
+	}
+
+	security, err := os.ReadFile(filepath.Join(dir, "SECURITY.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(security), "Send a report to security@acme.dev.") {
+		t.Errorf("expected the security contact, got:\n%s", security)
+	}
+}
+
+func TestValidateOwner(t *testing.T) {
+	for _, owner := range []string{"@jane", "@acme/platform", "jane@acme.dev"} {
+		if err := ValidateOwner(owner); err != nil {
+			t.Errorf("expected %s to be valid, got %v", owner, err)
+		}
+	}
+	for _, owner := range []string{"jane", "@", "@acme team", "jane@"} {
+		if err := ValidateOwner(owner); err == nil {
+			t.Errorf("expected %s to be invalid", owner)
+		}
+	}
+}
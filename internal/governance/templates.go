@@ -0,0 +1,215 @@
+package governance
+
+const codeownersTemplate = `# Code owners are requested for review on every change, see
+{{- if eq .Provider "gitlab"}}
+# https://docs.gitlab.com/ee/user/project/codeowners/
+{{- else}}
+# https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+{{- end}}
+*{{range .Owners}} {{.}}{{end}}
+`
+
+const githubBugReportTemplate = `name: Bug report
+description: Report something that does not work as expected
+labels: [bug]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to report a bug. Please search the existing issues first.
+  - type: textarea
+    id: description
+    attributes:
+      label: What happened?
+      description: A clear description of the bug.
+    validations:
+      required: true
+  - type: textarea
+    id: reproduction
+    attributes:
+      label: Steps to reproduce
+      placeholder: |
+        1. ...
+        2. ...
+    validations:
+      required: true
+  - type: textarea
+    id: expected
+    attributes:
+      label: Expected behavior
+    validations:
+      required: true
+  - type: input
+    id: version
+    attributes:
+      label: Version
+      description: The version or commit you are running.
+  - type: textarea
+    id: logs
+    attributes:
+      label: Relevant logs
+      render: shell
+`
+
+const githubFeatureRequestTemplate = `name: Feature request
+description: Suggest an idea or an improvement
+labels: [enhancement]
+body:
+  - type: textarea
+    id: problem
+    attributes:
+      label: Problem
+      description: What problem would this feature solve?
+    validations:
+      required: true
+  - type: textarea
+    id: solution
+    attributes:
+      label: Proposed solution
+    validations:
+      required: true
+  - type: textarea
+    id: alternatives
+    attributes:
+      label: Alternatives considered
+`
+
+const gitlabBugReportTemplate = `## What happened?
+
+<!-- A clear description of the bug. -->
+
+## Steps to reproduce
+
+1.
+2.
+
+## Expected behavior
+
+## Version
+
+<!-- The version or commit you are running. -->
+
+## Relevant logs
+
+` + "```shell\n```" + `
+
+/label ~bug
+`
+
+const gitlabFeatureRequestTemplate = `## Problem
+
+<!-- What problem would this feature solve? -->
+
+## Proposed solution
+
+## Alternatives considered
+
+/label ~enhancement
+`
+
+const pullRequestTemplate = `## Summary
+
+<!-- What does this change do and why? Link the related issue, e.g. Closes #123. -->
+
+## Testing
+
+<!-- How did you verify the change? -->
+
+## Checklist
+
+- [ ] Tests cover the change
+{{- range .Checks}}
+- [ ] ` + "`{{.}}`" + ` passes
+{{- end}}
+- [ ] Documentation is updated
+`
+
+const contributingTemplate = `# Contributing to {{.Name}}
+
+Thanks for your interest in contributing! This document explains how to set up the
+project and get a change merged.
+
+## Development setup
+{{range .Projects}}
+{{- if $.Multiple}}
+### {{.Path}}
+{{end}}
+` + "```bash" + `
+{{- if ne .Path "."}}
+cd {{.Path}}
+{{- end}}
+{{- range .Setup}}
+{{.}}
+{{- end}}
+` + "```" + `
+{{end}}
+## Making changes
+
+1. Create a branch from the default branch.
+2. Make your change and add tests for it.
+3. Run the checks below before pushing.
+{{- if .Hooks}}
+4. Install the Git hooks once with ` + "`{{.Hooks}} install`" + `, they run the linters before every commit.
+{{- end}}
+
+` + "```bash" + `
+{{- range .Checks}}
+{{.}}
+{{- end}}
+` + "```" + `
+
+## Commit messages
+{{if .ConventionalCommits}}
+Commit messages follow [Conventional Commits](https://www.conventionalcommits.org), e.g.
+` + "`feat(api): add users endpoint`" + `. A commit-msg hook rejects other messages.
+{{- else}}
+Write commit messages in the imperative mood and keep the subject line under 72 characters.
+{{- end}}
+
+## Submitting a {{.RequestName}}
+
+Open a {{.RequestName}} against the default branch and fill in the template. The code
+owners are asked for a review automatically, and every check has to pass before merging.
+
+## Reporting security issues
+
+Please do not open public issues for vulnerabilities, see [SECURITY.md](SECURITY.md).
+`
+
+const securityTemplate = `# Security Policy
+
+## Supported versions
+
+Security fixes are made for the latest release only.
+
+## Reporting a vulnerability
+
+Please do not report security vulnerabilities through public issues.
+{{if .Contact}}
+Send a report to {{.Contact}}.
+{{- else if eq .Provider "gitlab"}}
+Open a [confidential issue](https://docs.gitlab.com/ee/user/project/issues/confidential_issues.html)
+and mention the code owners.
+{{- else}}
+Use [private vulnerability reporting](https://docs.github.com/en/code-security/security-advisories/guidance-on-reporting-and-writing-information-about-vulnerabilities/privately-reporting-a-security-vulnerability)
+from the Security tab of the repository.
+{{- end}}
+
+Include a description of the issue, the steps to reproduce it and the affected
+versions. You will receive an answer within five working days, and we will keep you
+informed until a fix is released.
+`
+
+const dependabotTemplate = `# Dependency updates, see
+# https://docs.github.com/en/code-security/dependabot/dependabot-version-updates/configuration-options-for-the-dependabot.yml-file
+version: 2
+updates:
+{{- range .Ecosystems}}
+  - package-ecosystem: {{.Dependabot}}
+    directory: {{.Directory}}
+    schedule:
+      interval: weekly
+    groups:
+      {{.Name}}:
+        update-types: [minor, patch]
+{{- end}}
+`
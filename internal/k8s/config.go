@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// SecretMode is how the secret variables of the .env file are written
+type SecretMode string
+
+const (
+	// PlainSecret writes a Secret holding the values
+	PlainSecret SecretMode = "plain"
+	// SealedSecret writes a Bitnami SealedSecret with placeholders to fill with kubeseal
+	SealedSecret SecretMode = "sealed"
+	// ExternalSecret writes an External Secrets Operator ExternalSecret reading the
+	// values from a secret store
+	ExternalSecret SecretMode = "external"
+)
+
+// ParseSecretMode validates a secret mode
+func ParseSecretMode(name string) (SecretMode, error) {
+	switch mode := SecretMode(strings.ToLower(name)); mode {
+	case PlainSecret, SealedSecret, ExternalSecret:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported secret mode %q, use %s, %s or %s", name, PlainSecret, SealedSecret, ExternalSecret)
+	}
+}
+
+// DefaultSecretPatterns match the keys of the variables written into the Secret
+var DefaultSecretPatterns = []string{"*PASSWORD*", "*_PASS", "*SECRET*", "*TOKEN*", "*_KEY", "*CREDENTIALS*"}
+
+// envKey matches the keys allowed in ConfigMaps and Secrets
+var envKey = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// envReference matches a ${VAR} reference in a .env value
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// SplitEnv splits the variables of a .env file into configuration and secrets. A
+// variable is secret when its key matches one of the patterns, case-insensitively,
+// or when its value references a secret variable, like a DATABASE_URL embedding
+// ${DB_PASSWORD}. References to variables defined earlier in the file are expanded,
+// since envFrom does not interpolate values.
+func SplitEnv(vars []utils.EnvVar, patterns []string) (config []utils.EnvVar, secrets []utils.EnvVar, err error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, fmt.Errorf("invalid secret pattern %q: %v", pattern, err)
+		}
+	}
+
+	values := map[string]string{}
+	secret := map[string]bool{}
+	for _, v := range vars {
+		if !envKey.MatchString(v.Key) {
+			return nil, nil, fmt.Errorf("invalid variable name %q, use letters, digits, -, _ and .", v.Key)
+		}
+
+		isSecret := false
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(strings.ToUpper(pattern), strings.ToUpper(v.Key)); matched {
+				isSecret = true
+			}
+		}
+		value := envReference.ReplaceAllStringFunc(v.Value, func(reference string) string {
+			name := envReference.FindStringSubmatch(reference)[1]
+			if secret[name] {
+				isSecret = true
+			}
+			if expanded, ok := values[name]; ok {
+				return expanded
+			}
+			return reference
+		})
+
+		values[v.Key] = value
+		secret[v.Key] = isSecret
+		if isSecret {
+			secrets = append(secrets, utils.EnvVar{Key: v.Key, Value: value})
+		} else {
+			config = append(config, utils.EnvVar{Key: v.Key, Value: value})
+		}
+	}
+	return config, secrets, nil
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the configuration
+func (g *ManifestGenerator) ConfigMapName() string {
+	return g.AppName + "-config"
+}
+
+// SecretName returns the name of the Secret holding the secrets, also the name of
+// the Secret created from a SealedSecret or an ExternalSecret
+func (g *ManifestGenerator) SecretName() string {
+	return g.AppName + "-secret"
+}
+
+// SecretFile returns the file name of the secret manifest for the secret mode
+func (g *ManifestGenerator) SecretFile() string {
+	switch g.SecretMode {
+	case SealedSecret:
+		return "sealed-secret.yaml"
+	case ExternalSecret:
+		return "external-secret.yaml"
+	default:
+		return "secret.yaml"
+	}
+}
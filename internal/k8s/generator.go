@@ -1,10 +1,15 @@
 package k8s
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/utils"
 )
 
 // ManifestGenerator generates Kubernetes manifests
@@ -16,6 +21,72 @@ type ManifestGenerator struct {
 	Port          int
 	WithService   bool
 	WithIngress   bool
+	// Kind is the workload resource running the container
+	Kind Kind
+	// Storage is the volume claimed by each pod of a StatefulSet
+	Storage Storage
+	// Schedule configures when a CronJob runs
+	Schedule Schedule
+	// Ingress configures the hosts, path, class and TLS of the exposed Service
+	Ingress Ingress
+	// Gateway exposes the Service through a Gateway API HTTPRoute attached to this
+	// Gateway instead of an Ingress
+	Gateway *Gateway
+	// Image is the container image, its repository defaults to ContainerName
+	Image    Image
+	Replicas int
+	// PullPolicy is the image pull policy, left to the cluster default when empty
+	PullPolicy string
+	Resources  Resources
+	// Probes are the health checks of the container
+	Probes Probes
+	// Config is written into a ConfigMap and Secrets into a Secret, both loaded
+	// into the container with envFrom
+	Config  []utils.EnvVar
+	Secrets []utils.EnvVar
+	// SecretMode selects a plain Secret, a SealedSecret or an ExternalSecret
+	SecretMode SecretMode
+	// Autoscaling adds a HorizontalPodAutoscaler owning the replica count
+	Autoscaling *Autoscaling
+	// DisruptionBudget adds a PodDisruptionBudget
+	DisruptionBudget *DisruptionBudget
+	// Security hardens the pods and runs them under a dedicated ServiceAccount, the
+	// cluster defaults apply when nil
+	Security *Security
+	// NetworkPolicy restricts the traffic reaching the pods to the ingress controller
+	NetworkPolicy *NetworkPolicy
+	// Kustomize writes the manifests into k8s/base with a kustomization.yaml, and an
+	// overlay per environment into k8s/overlays
+	Kustomize bool
+	// Environments are the overlays written in Kustomize mode
+	Environments []Environment
+	// Helm writes a Helm chart into charts/<app-name> instead of the manifests
+	Helm bool
+}
+
+// Environment is a Kustomize overlay patching the base manifests for one deployment
+// target
+type Environment struct {
+	Name      string
+	Replicas  int
+	Resources Resources
+	// ImageTag replaces the latest tag of the base image, an image pinned by digest
+	// keeps its digest in every environment
+	ImageTag string
+	// Hosts are the ingress hosts of the environment, in the order of Ingress.Hosts
+	Hosts []string
+}
+
+// DefaultEnvironments are the overlays written when none are given
+var DefaultEnvironments = []string{"dev", "staging", "prod"}
+
+// ValidateEnvironment checks that an environment name is a lowercase DNS label,
+// since it names the overlay directory, the image tag and the ingress subdomain
+func ValidateEnvironment(name string) error {
+	if !dnsLabel.MatchString(name) {
+		return fmt.Errorf("invalid environment name %q, use lowercase letters, digits and hyphens", name)
+	}
+	return nil
 }
 
 // NewManifestGenerator creates a new ManifestGenerator
@@ -28,21 +99,173 @@ func NewManifestGenerator(appName, projectName, containerName, namespace string,
 		Port:          port,
 		WithService:   withService,
 		WithIngress:   withIngress,
+		Kind:          Deployment,
+		Storage:       Storage{Size: "1Gi", MountPath: "/data"},
+		Schedule:      Schedule{ConcurrencyPolicy: "Forbid"},
+		Ingress:       Ingress{Hosts: []string{appName + ".example.com"}, Path: "/"},
+		Image:         Image{Repository: containerName, Tag: "latest"},
+		Replicas:      1,
+		Resources:     Sizes[DefaultSize],
+		SecretMode:    PlainSecret,
+		Security:      &Security{RunAsUser: DefaultUser},
 	}
 }
 
+// Validate checks the image, replicas, pull policy and resources before any file is
+// written
+func (g *ManifestGenerator) Validate() error {
+	if err := g.validateKind(); err != nil {
+		return err
+	}
+	if err := g.Image.Validate(); err != nil {
+		return err
+	}
+	if g.Replicas < 0 {
+		return fmt.Errorf("invalid replicas %d, use 0 or more", g.Replicas)
+	}
+	if g.PullPolicy != "" {
+		if _, err := ParsePullPolicy(g.PullPolicy); err != nil {
+			return err
+		}
+	}
+	if err := g.Resources.Validate(); err != nil {
+		return err
+	}
+	for _, probe := range g.Probes.List() {
+		if err := probe.Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %v", probe.Key, err)
+		}
+	}
+	if g.WithIngress {
+		if err := g.Ingress.Validate(); err != nil {
+			return err
+		}
+		if g.Gateway != nil && (g.Ingress.TLSSecret != "" || g.Ingress.ClassName != "" || len(g.Ingress.AllAnnotations()) > 0) {
+			return fmt.Errorf("TLS, ingress classes and annotations are configured on the Gateway, not on an HTTPRoute")
+		}
+	}
+	minReplicas := g.Replicas
+	if g.Autoscaling != nil {
+		if err := g.Autoscaling.Validate(); err != nil {
+			return err
+		}
+		minReplicas = g.Autoscaling.MinReplicas
+	}
+	if g.DisruptionBudget != nil {
+		if err := g.DisruptionBudget.Validate(minReplicas); err != nil {
+			return err
+		}
+	}
+	if g.Security != nil {
+		if err := g.Security.Validate(); err != nil {
+			return err
+		}
+	}
+	if g.NetworkPolicy != nil {
+		if err := g.NetworkPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseSecretMode(string(g.SecretMode)); err != nil {
+		return err
+	}
+	if g.Helm && g.SecretMode != PlainSecret && len(g.Secrets) > 0 {
+		return fmt.Errorf("the Helm chart only supports %s secrets, override their values at install time instead", PlainSecret)
+	}
+	for _, env := range g.Environments {
+		if err := env.Resources.Validate(); err != nil {
+			return fmt.Errorf("environment %s: %v", env.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateKind checks that the requested resources fit the workload kind: Jobs and
+// CronJobs serve no traffic, and only Deployments and StatefulSets have replicas to
+// autoscale or protect with a disruption budget
+func (g *ManifestGenerator) validateKind() error {
+	if _, err := ParseKind(string(g.Kind)); err != nil {
+		return err
+	}
+	if !g.Kind.Serving() {
+		if g.WithService || g.WithIngress {
+			return fmt.Errorf("a %s runs to completion and serves no traffic, drop the service and ingress", g.Kind)
+		}
+		if len(g.Probes.List()) > 0 {
+			return fmt.Errorf("a %s runs to completion, drop the probes", g.Kind)
+		}
+		if g.NetworkPolicy != nil {
+			return fmt.Errorf("a %s serves no traffic to admit with a network policy", g.Kind)
+		}
+	}
+	if !g.Kind.Replicated() && (g.Autoscaling != nil || g.DisruptionBudget != nil) {
+		return fmt.Errorf("a %s has no replica count to autoscale or protect with a disruption budget", g.Kind)
+	}
+	switch g.Kind {
+	case StatefulSet:
+		return g.Storage.Validate()
+	case CronJob:
+		return g.Schedule.Validate()
+	}
+	return nil
+}
+
+// NewEnvironment returns the overlay settings of an environment. dev runs a single
+// small replica, prod runs three large ones served from the ingress hosts, and any
+// other environment gets two medium replicas. Non-production environments are
+// served from a subdomain of each ingress host named after the environment.
+func (g *ManifestGenerator) NewEnvironment(name string) Environment {
+	env := Environment{
+		Name:      name,
+		Replicas:  2,
+		Resources: Sizes["medium"],
+		ImageTag:  name,
+		Hosts:     g.Ingress.EnvironmentHosts(name),
+	}
+	switch name {
+	case "dev":
+		env.Replicas = 1
+		env.Resources = Sizes["small"]
+	case "prod":
+		env.Replicas = 3
+		env.Resources = Sizes["large"]
+	}
+	return env
+}
+
 // Generate generates Kubernetes manifests in the specified directory
 func (g *ManifestGenerator) Generate(outputDir string) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+
+	if g.Helm {
+		return g.generateChart(filepath.Join(outputDir, g.ChartDir()))
+	}
+
 	// Create manifest directory if it doesn't exist
 	k8sDir := filepath.Join(outputDir, "k8s")
+	if g.Kustomize {
+		k8sDir = filepath.Join(k8sDir, "base")
+	}
 	if err := os.MkdirAll(k8sDir, 0755); err != nil {
 		return fmt.Errorf("failed to create k8s directory: %v", err)
 	}
 
-	// Always generate deployment manifest
-	if err := g.generateDeployment(k8sDir); err != nil {
+	// Always generate the workload manifest, and the headless Service of StatefulSets
+	if err := g.generateManifest(filepath.Join(k8sDir, g.Kind.File()), workloadTemplate); err != nil {
 		return err
 	}
+	if g.Kind == StatefulSet {
+		if err := g.generateManifest(filepath.Join(k8sDir, "headless-service.yaml"), headlessServiceTemplate); err != nil {
+			return err
+		}
+	}
+	if g.Security != nil {
+		if err := g.generateManifest(filepath.Join(k8sDir, "serviceaccount.yaml"), serviceAccountTemplate); err != nil {
+			return err
+		}
+	}
 
 	// Generate service manifest if requested
 	if g.WithService {
@@ -51,19 +274,188 @@ func (g *ManifestGenerator) Generate(outputDir string) error {
 		}
 	}
 
-	// Generate ingress manifest if requested
-	if g.WithIngress {
+	// Generate ingress manifest if requested, or the HTTPRoute in Gateway mode
+	if g.WithIngress && g.Gateway != nil {
+		if err := g.generateManifest(filepath.Join(k8sDir, "httproute.yaml"), httpRouteTemplate); err != nil {
+			return err
+		}
+	} else if g.WithIngress {
 		if err := g.generateIngress(k8sDir); err != nil {
 			return err
 		}
 	}
 
+	// Generate the autoscaler and disruption budget if requested
+	if g.Autoscaling != nil {
+		if err := g.generateManifest(filepath.Join(k8sDir, "hpa.yaml"), hpaTemplate); err != nil {
+			return err
+		}
+	}
+	if g.DisruptionBudget != nil {
+		if err := g.generateManifest(filepath.Join(k8sDir, "pdb.yaml"), pdbTemplate); err != nil {
+			return err
+		}
+	}
+	if g.NetworkPolicy != nil {
+		if err := g.generateManifest(filepath.Join(k8sDir, "networkpolicy.yaml"), networkPolicyTemplate); err != nil {
+			return err
+		}
+	}
+
+	// Generate the configuration read from the .env file
+	if len(g.Config) > 0 {
+		if err := g.generateManifest(filepath.Join(k8sDir, "configmap.yaml"), configMapTemplate); err != nil {
+			return err
+		}
+	}
+	if len(g.Secrets) > 0 {
+		if err := g.generateManifest(filepath.Join(k8sDir, g.SecretFile()), g.secretTemplate()); err != nil {
+			return err
+		}
+	}
+
+	if g.Kustomize {
+		return g.generateKustomize(filepath.Join(outputDir, "k8s"))
+	}
+	return nil
+}
+
+// Manifests returns the manifests listed in the base kustomization.yaml
+func (g *ManifestGenerator) Manifests() []string {
+	manifests := []string{g.Kind.File()}
+	if g.Kind == StatefulSet {
+		manifests = append(manifests, "headless-service.yaml")
+	}
+	if g.Security != nil {
+		manifests = append(manifests, "serviceaccount.yaml")
+	}
+	if g.WithService {
+		manifests = append(manifests, "service.yaml")
+	}
+	if g.WithIngress && g.Gateway != nil {
+		manifests = append(manifests, "httproute.yaml")
+	} else if g.WithIngress {
+		manifests = append(manifests, "ingress.yaml")
+	}
+	if g.Autoscaling != nil {
+		manifests = append(manifests, "hpa.yaml")
+	}
+	if g.DisruptionBudget != nil {
+		manifests = append(manifests, "pdb.yaml")
+	}
+	if g.NetworkPolicy != nil {
+		manifests = append(manifests, "networkpolicy.yaml")
+	}
+	if len(g.Config) > 0 {
+		manifests = append(manifests, "configmap.yaml")
+	}
+	if len(g.Secrets) > 0 {
+		manifests = append(manifests, g.SecretFile())
+	}
+	return manifests
+}
+
+// generateKustomize writes the base kustomization.yaml and the overlays. The patches
+// are inlined in the overlay kustomization.yaml so that every file left in k8s/ is
+// either a complete manifest or a Kustomization, which keeps kubeconform happy.
+func (g *ManifestGenerator) generateKustomize(k8sDir string) error {
+	if err := g.generateFile(filepath.Join(k8sDir, "base", "kustomization.yaml"), kustomizationTemplate, g); err != nil {
+		return err
+	}
+
+	environments := g.Environments
+	if len(environments) == 0 {
+		for _, name := range DefaultEnvironments {
+			environments = append(environments, g.NewEnvironment(name))
+		}
+	}
+
+	for _, env := range environments {
+		overlayDir := filepath.Join(k8sDir, "overlays", env.Name)
+		if err := os.MkdirAll(overlayDir, 0755); err != nil {
+			return fmt.Errorf("failed to create overlay directory %s: %v", env.Name, err)
+		}
+		data := struct {
+			*ManifestGenerator
+			Env Environment
+		}{g, env}
+		if err := g.generateFile(filepath.Join(overlayDir, "kustomization.yaml"), overlayTemplate, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChartDir returns the directory of the Helm chart relative to the output directory
+func (g *ManifestGenerator) ChartDir() string {
+	return filepath.Join("charts", g.AppName)
+}
+
+// ChartFiles returns the templates of the Helm chart keyed by their path relative
+// to the chart directory. The templates that cannot apply to the workload kind are
+// left out.
+func (g *ManifestGenerator) ChartFiles() map[string]string {
+	files := map[string]string{
+		"Chart.yaml":                    chartTemplate,
+		"values.yaml":                   valuesTemplate,
+		".helmignore":                   helmignoreTemplate,
+		"templates/_helpers.tpl":        helpersTemplate,
+		"templates/" + g.Kind.File():    chartPodTemplate + chartWorkloadTemplate,
+		"templates/serviceaccount.yaml": chartServiceAccountTemplate,
+		"templates/configmap.yaml":      chartConfigMapTemplate,
+		"templates/secret.yaml":         chartSecretTemplate,
+		"templates/NOTES.txt":           notesTemplate,
+	}
+	if g.Kind.Serving() {
+		files["templates/service.yaml"] = chartServiceTemplate
+		files["templates/ingress.yaml"] = chartIngressTemplate
+		files["templates/httproute.yaml"] = chartHTTPRouteTemplate
+		files["templates/networkpolicy.yaml"] = chartNetworkPolicyTemplate
+	}
+	if g.Kind.Replicated() {
+		files["templates/hpa.yaml"] = chartHPATemplate
+		files["templates/pdb.yaml"] = chartPDBTemplate
+	}
+	if g.Kind == StatefulSet {
+		files["templates/headless-service.yaml"] = chartHeadlessServiceTemplate
+	}
+	return files
+}
+
+// generateChart writes the Helm chart. The service and ingress templates are written
+// for every serving kind, WithService and WithIngress set their enabled values.
+func (g *ManifestGenerator) generateChart(chartDir string) error {
+	if err := os.MkdirAll(filepath.Join(chartDir, "templates"), 0755); err != nil {
+		return fmt.Errorf("failed to create chart directory: %v", err)
+	}
+
+	for name, content := range g.ChartFiles() {
+		tmpl, err := parseTemplate(name, content, true)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s template: %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, g); err != nil {
+			return fmt.Errorf("failed to render %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(chartDir, filepath.FromSlash(name)), buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", name, err)
+		}
+	}
 	return nil
 }
 
-func (g *ManifestGenerator) generateDeployment(k8sDir string) error {
-	deploymentPath := filepath.Join(k8sDir, "deployment.yaml")
-	return g.generateManifest(deploymentPath, deploymentTemplate)
+// secretTemplate returns the template of the secret manifest for the secret mode
+func (g *ManifestGenerator) secretTemplate() string {
+	switch g.SecretMode {
+	case SealedSecret:
+		return sealedSecretTemplate
+	case ExternalSecret:
+		return externalSecretTemplate
+	default:
+		return secretTemplate
+	}
 }
 
 func (g *ManifestGenerator) generateService(k8sDir string) error {
@@ -77,8 +469,12 @@ func (g *ManifestGenerator) generateIngress(k8sDir string) error {
 }
 
 func (g *ManifestGenerator) generateManifest(filePath string, templateContent string) error {
+	return g.generateFile(filePath, templateContent, g)
+}
+
+func (g *ManifestGenerator) generateFile(filePath string, templateContent string, data interface{}) error {
 	// Parse template
-	tmpl, err := template.New(filepath.Base(filePath)).Parse(templateContent)
+	tmpl, err := parseTemplate(filepath.Base(filePath), templateContent, false)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %v", err)
 	}
@@ -91,9 +487,49 @@ func (g *ManifestGenerator) generateManifest(filePath string, templateContent st
 	defer file.Close()
 
 	// Execute template
-	if err := tmpl.Execute(file, g); err != nil {
+	if err := tmpl.Execute(file, data); err != nil {
 		return fmt.Errorf("failed to execute template: %v", err)
 	}
 
 	return nil
 }
+
+// parseTemplate parses a manifest template along with the shared partials. Chart
+// templates use [[ ]] delimiters, leaving the {{ }} actions to Helm.
+func parseTemplate(name string, content string, chart bool) (*template.Template, error) {
+	partials := probeTemplate + podTemplate
+	tmpl := template.New(name)
+	if chart {
+		partials = strings.NewReplacer("{{", "[[", "}}", "]]").Replace(partials)
+		tmpl = tmpl.Delims("[[", "]]")
+	}
+
+	tmpl.Funcs(template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			var buf bytes.Buffer
+			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return strings.Trim(buf.String(), "\n"), nil
+		},
+		"indent": func(spaces int, text string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
+		},
+		"add": func(a, b int) int {
+			return a + b
+		},
+		"lower": func(kind Kind) string {
+			return strings.ToLower(string(kind))
+		},
+		"quote": func(value string) string {
+			quoted, _ := json.Marshal(value)
+			return string(quoted)
+		},
+	})
+
+	if _, err := tmpl.Parse(partials); err != nil {
+		return nil, err
+	}
+	return tmpl.Parse(content)
+}
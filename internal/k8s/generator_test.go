@@ -0,0 +1,1068 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerate_Flat(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "acme/api", "default", 8080, true, false)
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{"deployment.yaml", "service.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, "k8s", name)); err != nil {
+			t.Errorf("expected k8s/%s to be generated", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "k8s", "ingress.yaml")); err == nil {
+		t.Error("expected no ingress without --ingress")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "k8s", "base")); err == nil {
+		t.Error("expected no Kustomize base by default")
+	}
+}
+
+func TestGenerate_Workload(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, false, false)
+	generator.Image = Image{Registry: "ghcr.io/acme", Repository: "api", Tag: "1.2.0"}
+	generator.Replicas = 3
+	generator.PullPolicy = "Always"
+	generator.Resources = Sizes["large"]
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var deployment struct {
+		Spec struct {
+			Replicas int `yaml:"replicas"`
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						Image           string `yaml:"image"`
+						ImagePullPolicy string `yaml:"imagePullPolicy"`
+						Resources       struct {
+							Limits map[string]string `yaml:"limits"`
+						} `yaml:"resources"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(dir, "k8s", "deployment.yaml"), &deployment)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if deployment.Spec.Replicas != 3 || container.Image != "ghcr.io/acme/api:1.2.0" || container.ImagePullPolicy != "Always" {
+		t.Errorf("unexpected deployment: %+v", deployment.Spec)
+	}
+	if container.Resources.Limits["cpu"] != "1" || container.Resources.Limits["memory"] != "512Mi" {
+		t.Errorf("unexpected limits: %v", container.Resources.Limits)
+	}
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	generator.Image.Digest = digest
+	if ref := generator.Image.Reference(); ref != "ghcr.io/acme/api@"+digest {
+		t.Errorf("expected the digest to pin the image, got %s", ref)
+	}
+
+	generator.Resources.MemoryRequest = "1Gi"
+	if err := generator.Generate(dir); err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+		t.Errorf("expected an error for a request above the limit, got %v", err)
+	}
+}
+
+func TestGenerate_Probes(t *testing.T) {
+	dir := t.TempDir()
+
+	project := &projects.ProjectInfo{Dir: dir, Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, false, false)
+	generator.Probes = DefaultProbes(project)
+	generator.Probes.Readiness = &Probe{Type: ExecProbe, Command: []string{"cat", "/tmp/ready"}, TimeoutSeconds: 2}
+	generator.Probes.Startup = nil
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	type probe struct {
+		HTTPGet *struct {
+			Path string `yaml:"path"`
+			Port string `yaml:"port"`
+		} `yaml:"httpGet"`
+		Exec *struct {
+			Command []string `yaml:"command"`
+		} `yaml:"exec"`
+		PeriodSeconds    int `yaml:"periodSeconds"`
+		TimeoutSeconds   int `yaml:"timeoutSeconds"`
+		FailureThreshold int `yaml:"failureThreshold"`
+	}
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						LivenessProbe  *probe `yaml:"livenessProbe"`
+						ReadinessProbe *probe `yaml:"readinessProbe"`
+						StartupProbe   *probe `yaml:"startupProbe"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(dir, "k8s", "deployment.yaml"), &deployment)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	liveness := container.LivenessProbe
+	if liveness == nil || liveness.HTTPGet == nil || liveness.HTTPGet.Path != "/healthz" || liveness.HTTPGet.Port != "http" || liveness.PeriodSeconds != 10 {
+		t.Errorf("unexpected liveness probe: %+v", liveness)
+	}
+	readiness := container.ReadinessProbe
+	if readiness == nil || readiness.Exec == nil || !reflect.DeepEqual(readiness.Exec.Command, []string{"cat", "/tmp/ready"}) || readiness.TimeoutSeconds != 2 {
+		t.Errorf("unexpected readiness probe: %+v", readiness)
+	}
+	if container.StartupProbe != nil {
+		t.Errorf("expected no startup probe, got %+v", container.StartupProbe)
+	}
+
+	generator.Helm = true
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var values struct {
+		LivenessProbe  probe                  `yaml:"livenessProbe"`
+		ReadinessProbe probe                  `yaml:"readinessProbe"`
+		StartupProbe   map[string]interface{} `yaml:"startupProbe"`
+	}
+	readYAML(t, filepath.Join(dir, "charts", "api", "values.yaml"), &values)
+	if values.LivenessProbe.HTTPGet == nil || values.LivenessProbe.HTTPGet.Path != "/healthz" || values.ReadinessProbe.Exec == nil || len(values.StartupProbe) != 0 {
+		t.Errorf("unexpected probe values: %+v", values)
+	}
+}
+
+func TestParseProbe(t *testing.T) {
+	valid := map[string]*Probe{
+		"http:/healthz":      {Type: HTTPProbe, Path: "/healthz"},
+		"tcp":                {Type: TCPProbe},
+		"tcp:5432":           {Type: TCPProbe, Port: 5432},
+		"grpc:9090:health":   {Type: GRPCProbe, Port: 9090, Service: "health"},
+		"exec:pg_isready -q": {Type: ExecProbe, Command: []string{"pg_isready", "-q"}},
+		"none":               nil,
+	}
+	for spec, want := range valid {
+		got, err := ParseProbe(spec)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %s to parse as %+v, got %+v %v", spec, want, got, err)
+		}
+	}
+	for _, spec := range []string{"http", "http:healthz", "tcp:http", "grpc:70000", "exec:", "ping"} {
+		if _, err := ParseProbe(spec); err == nil {
+			t.Errorf("expected %q to be invalid", spec)
+		}
+	}
+
+	if probes := DefaultProbes(&projects.ProjectInfo{Type: projects.NodeJS, NodeType: projects.TypeScriptBasic}); len(probes.List()) != 0 {
+		t.Errorf("expected no probes for projects without a server, got %+v", probes)
+	}
+	if probes := DefaultProbes(&projects.ProjectInfo{Type: projects.NodeJS, NodeType: projects.NextJS}); probes.Startup == nil || probes.Startup.Path != "/" || probes.Startup.FailureThreshold != 30 {
+		t.Errorf("unexpected Next.js probes: %+v", probes)
+	}
+}
+
+func TestGenerate_Scaling(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, false, false)
+	generator.Kustomize = true
+	generator.Autoscaling = &Autoscaling{MinReplicas: 2, MaxReplicas: 4, CPUUtilization: 75}
+	generator.DisruptionBudget = &DisruptionBudget{MaxUnavailable: "25%"}
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	base := filepath.Join(dir, "k8s", "base")
+
+	var hpa struct {
+		APIVersion string `yaml:"apiVersion"`
+		Spec       struct {
+			ScaleTargetRef struct {
+				Kind string `yaml:"kind"`
+				Name string `yaml:"name"`
+			} `yaml:"scaleTargetRef"`
+			MinReplicas int `yaml:"minReplicas"`
+			MaxReplicas int `yaml:"maxReplicas"`
+			Metrics     []struct {
+				Resource struct {
+					Name string `yaml:"name"`
+				} `yaml:"resource"`
+			} `yaml:"metrics"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(base, "hpa.yaml"), &hpa)
+	if hpa.APIVersion != "autoscaling/v2" || hpa.Spec.ScaleTargetRef.Name != "api" || hpa.Spec.MinReplicas != 2 || hpa.Spec.MaxReplicas != 4 {
+		t.Errorf("unexpected HorizontalPodAutoscaler: %+v", hpa)
+	}
+	if len(hpa.Spec.Metrics) != 1 || hpa.Spec.Metrics[0].Resource.Name != "cpu" {
+		t.Errorf("expected a single CPU metric, got %+v", hpa.Spec.Metrics)
+	}
+
+	var pdb struct {
+		Spec struct {
+			MaxUnavailable string `yaml:"maxUnavailable"`
+			Selector       struct {
+				MatchLabels map[string]string `yaml:"matchLabels"`
+			} `yaml:"selector"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(base, "pdb.yaml"), &pdb)
+	if pdb.Spec.MaxUnavailable != "25%" || pdb.Spec.Selector.MatchLabels["app"] != "shop" {
+		t.Errorf("unexpected PodDisruptionBudget: %+v", pdb)
+	}
+
+	var deployment struct {
+		Spec map[string]interface{} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(base, "deployment.yaml"), &deployment)
+	if _, ok := deployment.Spec["replicas"]; ok {
+		t.Error("expected the autoscaler to own the replicas")
+	}
+
+	var overlay struct {
+		Replicas []interface{} `yaml:"replicas"`
+		Patches  []struct {
+			Target struct {
+				Kind string `yaml:"kind"`
+			} `yaml:"target"`
+			Patch string `yaml:"patch"`
+		} `yaml:"patches"`
+	}
+	readYAML(t, filepath.Join(dir, "k8s", "overlays", "prod", "kustomization.yaml"), &overlay)
+	if len(overlay.Replicas) != 0 || len(overlay.Patches) != 2 || overlay.Patches[1].Target.Kind != "HorizontalPodAutoscaler" {
+		t.Fatalf("expected the overlay to patch the autoscaler, got %+v", overlay)
+	}
+	if !strings.Contains(overlay.Patches[1].Patch, "path: /spec/minReplicas\n  value: 3\n- op: replace\n  path: /spec/maxReplicas\n  value: 4") {
+		t.Errorf("unexpected autoscaler patch:\n%s", overlay.Patches[1].Patch)
+	}
+}
+
+func TestScaling_Validate(t *testing.T) {
+	for _, autoscaling := range []Autoscaling{
+		{MinReplicas: 0, MaxReplicas: 3, CPUUtilization: 80},
+		{MinReplicas: 3, MaxReplicas: 2, CPUUtilization: 80},
+		{MinReplicas: 1, MaxReplicas: 2},
+		{MinReplicas: 1, MaxReplicas: 2, MemoryUtilization: 120},
+	} {
+		if err := autoscaling.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", autoscaling)
+		}
+	}
+
+	valid := []DisruptionBudget{{MinAvailable: "1"}, {MinAvailable: "50%"}, {MaxUnavailable: "2"}}
+	for _, budget := range valid {
+		if err := budget.Validate(2); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", budget, err)
+		}
+	}
+	invalid := []DisruptionBudget{{}, {MinAvailable: "1", MaxUnavailable: "1"}, {MinAvailable: "2"}, {MaxUnavailable: "one"}, {MinAvailable: "150%"}}
+	for _, budget := range invalid {
+		if err := budget.Validate(2); err == nil {
+			t.Errorf("expected %+v to be invalid", budget)
+		}
+	}
+}
+
+func TestGenerate_Ingress(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, true, true)
+	generator.Kustomize = true
+	generator.Ingress = Ingress{
+		Hosts:         []string{"api.acme.io", "www.acme.io"},
+		Path:          "/v1",
+		ClassName:     "nginx",
+		Annotations:   map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "8m"},
+		TLSSecret:     "api-tls",
+		ClusterIssuer: "letsencrypt",
+	}
+	generator.Environments = []Environment{generator.NewEnvironment("staging")}
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var ingress struct {
+		Metadata struct {
+			Annotations map[string]string `yaml:"annotations"`
+		} `yaml:"metadata"`
+		Spec struct {
+			IngressClassName string `yaml:"ingressClassName"`
+			TLS              []struct {
+				Hosts      []string `yaml:"hosts"`
+				SecretName string   `yaml:"secretName"`
+			} `yaml:"tls"`
+			Rules []struct {
+				Host string `yaml:"host"`
+				HTTP struct {
+					Paths []struct {
+						Path string `yaml:"path"`
+					} `yaml:"paths"`
+				} `yaml:"http"`
+			} `yaml:"rules"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(dir, "k8s", "base", "ingress.yaml"), &ingress)
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/proxy-body-size": "8m",
+		"cert-manager.io/cluster-issuer":              "letsencrypt",
+	}
+	if !reflect.DeepEqual(ingress.Metadata.Annotations, annotations) {
+		t.Errorf("expected annotations %v, got %v", annotations, ingress.Metadata.Annotations)
+	}
+	if ingress.Spec.IngressClassName != "nginx" {
+		t.Errorf("expected the nginx ingress class, got %q", ingress.Spec.IngressClassName)
+	}
+	if len(ingress.Spec.TLS) != 1 || ingress.Spec.TLS[0].SecretName != "api-tls" || !reflect.DeepEqual(ingress.Spec.TLS[0].Hosts, generator.Ingress.Hosts) {
+		t.Errorf("unexpected TLS: %+v", ingress.Spec.TLS)
+	}
+	if len(ingress.Spec.Rules) != 2 || ingress.Spec.Rules[1].Host != "www.acme.io" || ingress.Spec.Rules[1].HTTP.Paths[0].Path != "/v1" {
+		t.Errorf("expected a rule per host on /v1, got %+v", ingress.Spec.Rules)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "k8s", "overlays", "staging", "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("expected the staging overlay, got %v", err)
+	}
+	for _, want := range []string{
+		"path: /spec/rules/0/host\n        value: \"staging.api.acme.io\"",
+		"path: /spec/tls/0/hosts/0\n        value: \"staging.api.acme.io\"",
+		"path: /spec/rules/1/host\n        value: \"staging.www.acme.io\"",
+		"path: /spec/tls/0/hosts/1\n        value: \"staging.www.acme.io\"",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected the overlay to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerate_Gateway(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, true, true)
+	generator.Gateway = &Gateway{Name: "public", Namespace: "infra", SectionName: "https"}
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	k8sDir := filepath.Join(dir, "k8s")
+	if _, err := os.Stat(filepath.Join(k8sDir, "ingress.yaml")); !os.IsNotExist(err) {
+		t.Error("expected the HTTPRoute to replace the Ingress")
+	}
+
+	var route struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Spec       struct {
+			ParentRefs []map[string]string `yaml:"parentRefs"`
+			Hostnames  []string            `yaml:"hostnames"`
+			Rules      []struct {
+				BackendRefs []struct {
+					Name string `yaml:"name"`
+					Port int    `yaml:"port"`
+				} `yaml:"backendRefs"`
+			} `yaml:"rules"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(k8sDir, "httproute.yaml"), &route)
+	if route.APIVersion != "gateway.networking.k8s.io/v1" || route.Kind != "HTTPRoute" {
+		t.Errorf("unexpected route kind %s %s", route.APIVersion, route.Kind)
+	}
+	parent := map[string]string{"name": "public", "namespace": "infra", "sectionName": "https"}
+	if len(route.Spec.ParentRefs) != 1 || !reflect.DeepEqual(route.Spec.ParentRefs[0], parent) {
+		t.Errorf("expected parent %v, got %v", parent, route.Spec.ParentRefs)
+	}
+	if !reflect.DeepEqual(route.Spec.Hostnames, []string{"api.example.com"}) {
+		t.Errorf("expected the default host, got %v", route.Spec.Hostnames)
+	}
+	if len(route.Spec.Rules) != 1 || route.Spec.Rules[0].BackendRefs[0].Name != "api" || route.Spec.Rules[0].BackendRefs[0].Port != 80 {
+		t.Errorf("expected the route to target the Service, got %+v", route.Spec.Rules)
+	}
+
+	generator.Ingress.TLSSecret = "api-tls"
+	if err := generator.Validate(); err == nil {
+		t.Error("expected TLS to be rejected in Gateway mode")
+	}
+}
+
+func TestRouting_Validate(t *testing.T) {
+	for _, ingress := range []Ingress{
+		{Path: "/"},
+		{Hosts: []string{"API.example.com"}, Path: "/"},
+		{Hosts: []string{"api.example.com"}, Path: "api"},
+		{Hosts: []string{"api.example.com"}, Path: "/", ClusterIssuer: "letsencrypt"},
+	} {
+		if err := ingress.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", ingress)
+		}
+	}
+	valid := Ingress{Hosts: []string{"*.example.com"}, Path: "/", TLSSecret: "wildcard-tls", ClusterIssuer: "letsencrypt"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected %+v to be valid, got %v", valid, err)
+	}
+
+	ingress := Ingress{Hosts: []string{"example.com", "api.example.com", "*.example.com"}}
+	for env, want := range map[string][]string{
+		"dev":  {"dev.example.com", "dev.api.example.com", "*.dev.example.com"},
+		"prod": {"example.com", "api.example.com", "*.example.com"},
+	} {
+		if got := ingress.EnvironmentHosts(env); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected the %s hosts %v, got %v", env, want, got)
+		}
+	}
+
+	gateway, err := ParseGateway("infra/public")
+	if err != nil || gateway.Namespace != "infra" || gateway.Name != "public" {
+		t.Errorf("unexpected gateway %+v, %v", gateway, err)
+	}
+	for _, reference := range []string{"", "infra/", "a/b/c", "Public"} {
+		if _, err := ParseGateway(reference); err == nil {
+			t.Errorf("expected gateway %q to be invalid", reference)
+		}
+	}
+}
+
+func TestGenerate_Kinds(t *testing.T) {
+	type workload struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Spec       struct {
+			Replicas             *int   `yaml:"replicas"`
+			ServiceName          string `yaml:"serviceName"`
+			Schedule             string `yaml:"schedule"`
+			ConcurrencyPolicy    string `yaml:"concurrencyPolicy"`
+			VolumeClaimTemplates []struct {
+				Spec struct {
+					StorageClassName string `yaml:"storageClassName"`
+				} `yaml:"spec"`
+			} `yaml:"volumeClaimTemplates"`
+		} `yaml:"spec"`
+	}
+
+	for _, kind := range Kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			dir := t.TempDir()
+
+			serving := kind.Serving()
+			generator := NewManifestGenerator("api", "shop", "api", "default", 8080, serving, false)
+			generator.Kind = kind
+			generator.Kustomize = true
+			generator.Storage.StorageClass = "fast"
+			generator.Schedule.Cron = "*/15 * * * *"
+			if serving {
+				generator.Probes = NewProbes(Probe{Type: HTTPProbe, Path: "/healthz"})
+			}
+			if err := generator.Generate(dir); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			base := filepath.Join(dir, "k8s", "base")
+
+			var manifest workload
+			readYAML(t, filepath.Join(base, kind.File()), &manifest)
+			if manifest.Kind != string(kind) || manifest.APIVersion != kind.APIVersion() {
+				t.Errorf("unexpected workload %s %s", manifest.APIVersion, manifest.Kind)
+			}
+			if (manifest.Spec.Replicas != nil) != kind.Replicated() {
+				t.Errorf("expected replicas only on replicated kinds, got %v", manifest.Spec.Replicas)
+			}
+
+			content, err := os.ReadFile(filepath.Join(base, kind.File()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for snippet, want := range map[string]bool{
+				"containerPort: 8080":      serving,
+				"livenessProbe:":           serving,
+				"restartPolicy: OnFailure": !serving,
+				"mountPath: /data":         kind == StatefulSet,
+			} {
+				if strings.Contains(string(content), snippet) != want {
+					t.Errorf("expected %q in the %s: %v, got:\n%s", snippet, kind, want, content)
+				}
+			}
+
+			switch kind {
+			case StatefulSet:
+				if manifest.Spec.ServiceName != "api-headless" || len(manifest.Spec.VolumeClaimTemplates) != 1 || manifest.Spec.VolumeClaimTemplates[0].Spec.StorageClassName != "fast" {
+					t.Errorf("unexpected StatefulSet spec: %+v", manifest.Spec)
+				}
+				var headless struct {
+					Spec struct {
+						ClusterIP string `yaml:"clusterIP"`
+					} `yaml:"spec"`
+				}
+				readYAML(t, filepath.Join(base, "headless-service.yaml"), &headless)
+				if headless.Spec.ClusterIP != "None" {
+					t.Errorf("expected a headless Service, got %+v", headless)
+				}
+			case CronJob:
+				if manifest.Spec.Schedule != "*/15 * * * *" || manifest.Spec.ConcurrencyPolicy != "Forbid" {
+					t.Errorf("unexpected CronJob spec: %+v", manifest.Spec)
+				}
+			}
+
+			overlay, err := os.ReadFile(filepath.Join(dir, "k8s", "overlays", "prod", "kustomization.yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(overlay), "kind: "+string(kind)) || !strings.Contains(string(overlay), "path: "+kind.PodSpecPath()+"/containers/0/resources") {
+				t.Errorf("expected the overlay to patch the %s resources, got:\n%s", kind, overlay)
+			}
+			if strings.Contains(string(overlay), "replicas:") != kind.Replicated() {
+				t.Errorf("expected replicas in the overlay only on replicated kinds, got:\n%s", overlay)
+			}
+
+			generator.Helm = true
+			if err := generator.Generate(dir); err != nil {
+				t.Fatalf("expected no error generating the chart, got %v", err)
+			}
+			for name := range generator.ChartFiles() {
+				if _, err := os.Stat(filepath.Join(dir, "charts", "api", name)); err != nil {
+					t.Errorf("expected %s to be generated", name)
+				}
+			}
+			var values map[string]interface{}
+			readYAML(t, filepath.Join(dir, "charts", "api", "values.yaml"), &values)
+			if _, ok := values["service"]; ok != serving {
+				t.Errorf("expected service values only on serving kinds, got %v", values)
+			}
+		})
+	}
+}
+
+func TestKinds_Validate(t *testing.T) {
+	if kind, err := ParseKind("cronjob"); err != nil || kind != CronJob {
+		t.Errorf("expected cronjob to parse as CronJob, got %q, %v", kind, err)
+	}
+	if _, err := ParseKind("ReplicaSet"); err == nil {
+		t.Error("expected ReplicaSet to be unsupported")
+	}
+
+	invalid := map[string]func(g *ManifestGenerator){
+		"service on a Job": func(g *ManifestGenerator) { g.Kind, g.WithService = Job, true },
+		"probes on a CronJob": func(g *ManifestGenerator) {
+			g.Kind, g.Schedule.Cron, g.Probes = CronJob, "@hourly", NewProbes(Probe{Type: TCPProbe})
+		},
+		"autoscaled DaemonSet": func(g *ManifestGenerator) {
+			g.Kind, g.Autoscaling = DaemonSet, &Autoscaling{MinReplicas: 1, MaxReplicas: 2, CPUUtilization: 80}
+		},
+		"CronJob without schedule": func(g *ManifestGenerator) { g.Kind = CronJob },
+		"CronJob with 6 fields":    func(g *ManifestGenerator) { g.Kind, g.Schedule.Cron = CronJob, "0 0 * * * *" },
+		"unknown macro":            func(g *ManifestGenerator) { g.Kind, g.Schedule.Cron = CronJob, "@often" },
+		"empty volume":             func(g *ManifestGenerator) { g.Kind, g.Storage.Size = StatefulSet, "0" },
+		"relative mount path":      func(g *ManifestGenerator) { g.Kind, g.Storage.MountPath = StatefulSet, "data" },
+	}
+	for name, configure := range invalid {
+		generator := NewManifestGenerator("api", "shop", "api", "default", 8080, false, false)
+		configure(generator)
+		if err := generator.Validate(); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
+
+func TestGenerate_Security(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "default", 8080, true, true)
+	generator.NetworkPolicy = &NetworkPolicy{IngressNamespace: "traefik"}
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	k8sDir := filepath.Join(dir, "k8s")
+
+	type container struct {
+		SecurityContext struct {
+			AllowPrivilegeEscalation *bool `yaml:"allowPrivilegeEscalation"`
+			ReadOnlyRootFilesystem   bool  `yaml:"readOnlyRootFilesystem"`
+			Capabilities             struct {
+				Drop []string `yaml:"drop"`
+			} `yaml:"capabilities"`
+		} `yaml:"securityContext"`
+		VolumeMounts []struct {
+			MountPath string `yaml:"mountPath"`
+		} `yaml:"volumeMounts"`
+	}
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					ServiceAccountName           string `yaml:"serviceAccountName"`
+					AutomountServiceAccountToken *bool  `yaml:"automountServiceAccountToken"`
+					SecurityContext              struct {
+						RunAsNonRoot   bool `yaml:"runAsNonRoot"`
+						RunAsUser      int  `yaml:"runAsUser"`
+						SeccompProfile struct {
+							Type string `yaml:"type"`
+						} `yaml:"seccompProfile"`
+					} `yaml:"securityContext"`
+					Containers []container `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(k8sDir, "deployment.yaml"), &deployment)
+	pod := deployment.Spec.Template.Spec
+	if pod.ServiceAccountName != "api" || pod.AutomountServiceAccountToken == nil || *pod.AutomountServiceAccountToken {
+		t.Errorf("expected the api ServiceAccount without a token, got %+v", pod)
+	}
+	if !pod.SecurityContext.RunAsNonRoot || pod.SecurityContext.RunAsUser != DefaultUser || pod.SecurityContext.SeccompProfile.Type != "RuntimeDefault" {
+		t.Errorf("unexpected pod security context: %+v", pod.SecurityContext)
+	}
+	security := pod.Containers[0].SecurityContext
+	if security.AllowPrivilegeEscalation == nil || *security.AllowPrivilegeEscalation || !security.ReadOnlyRootFilesystem || !reflect.DeepEqual(security.Capabilities.Drop, []string{"ALL"}) {
+		t.Errorf("unexpected container security context: %+v", security)
+	}
+	if mounts := pod.Containers[0].VolumeMounts; len(mounts) != 1 || mounts[0].MountPath != "/tmp" {
+		t.Errorf("expected a writable /tmp, got %+v", mounts)
+	}
+
+	var account struct {
+		Kind                         string `yaml:"kind"`
+		AutomountServiceAccountToken *bool  `yaml:"automountServiceAccountToken"`
+	}
+	readYAML(t, filepath.Join(k8sDir, "serviceaccount.yaml"), &account)
+	if account.Kind != "ServiceAccount" || account.AutomountServiceAccountToken == nil || *account.AutomountServiceAccountToken {
+		t.Errorf("unexpected ServiceAccount: %+v", account)
+	}
+
+	content, err := os.ReadFile(filepath.Join(k8sDir, "networkpolicy.yaml"))
+	if err != nil {
+		t.Fatalf("expected a NetworkPolicy, got %v", err)
+	}
+	if !strings.Contains(string(content), "kubernetes.io/metadata.name: traefik") {
+		t.Errorf("expected the policy to admit the traefik namespace, got:\n%s", content)
+	}
+
+	dir = t.TempDir()
+	generator.Security, generator.NetworkPolicy = nil, nil
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "k8s", "deployment.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "securityContext") || strings.Contains(string(content), "serviceAccountName") {
+		t.Errorf("expected no hardening, got:\n%s", content)
+	}
+	for _, name := range []string{"serviceaccount.yaml", "networkpolicy.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, "k8s", name)); !os.IsNotExist(err) {
+			t.Errorf("expected no %s", name)
+		}
+	}
+
+	invalid := map[string]func(g *ManifestGenerator){
+		"root user":         func(g *ManifestGenerator) { g.Security = &Security{RunAsUser: 0} },
+		"invalid namespace": func(g *ManifestGenerator) { g.NetworkPolicy = &NetworkPolicy{IngressNamespace: "Ingress"} },
+		"network policy on a Job": func(g *ManifestGenerator) {
+			g.Kind, g.NetworkPolicy = Job, &NetworkPolicy{IngressNamespace: "ingress-nginx"}
+		},
+	}
+	for name, configure := range invalid {
+		generator := NewManifestGenerator("api", "shop", "api", "default", 8080, false, false)
+		configure(generator)
+		if err := generator.Validate(); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+}
+
+func TestSplitEnv(t *testing.T) {
+	vars := []utils.EnvVar{
+		{Key: "DB_HOST", Value: "localhost"},
+		{Key: "DB_PASSWORD", Value: "app"},
+		{Key: "DATABASE_URL", Value: "postgres://${DB_HOST}:${DB_PASSWORD}@db/${MISSING}"},
+		{Key: "REDIS_URL", Value: "redis://${DB_HOST}:6379"},
+		{Key: "stripe_api_key", Value: "sk_test"},
+	}
+	config, secrets, err := SplitEnv(vars, DefaultSecretPatterns)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	wantConfig := []utils.EnvVar{{Key: "DB_HOST", Value: "localhost"}, {Key: "REDIS_URL", Value: "redis://localhost:6379"}}
+	if !reflect.DeepEqual(config, wantConfig) {
+		t.Errorf("expected config %v, got %v", wantConfig, config)
+	}
+	wantSecrets := []utils.EnvVar{
+		{Key: "DB_PASSWORD", Value: "app"},
+		{Key: "DATABASE_URL", Value: "postgres://localhost:app@db/${MISSING}"},
+		{Key: "stripe_api_key", Value: "sk_test"},
+	}
+	if !reflect.DeepEqual(secrets, wantSecrets) {
+		t.Errorf("expected secrets %v, got %v", wantSecrets, secrets)
+	}
+
+	if _, _, err := SplitEnv([]utils.EnvVar{{Key: "BAD KEY", Value: "x"}}, nil); err == nil {
+		t.Error("expected an error for an invalid variable name")
+	}
+	if _, _, err := SplitEnv(nil, []string{"[A-"}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestGenerate_EnvFile(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "api", "prod", 8080, false, false)
+	generator.Kustomize = true
+	generator.Config = []utils.EnvVar{{Key: "LOG_LEVEL", Value: "info"}}
+	generator.Secrets = []utils.EnvVar{{Key: "API_TOKEN", Value: "t0ken: yes"}}
+	generator.SecretMode = SealedSecret
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	base := filepath.Join(dir, "k8s", "base")
+
+	var configMap struct {
+		Data map[string]string `yaml:"data"`
+	}
+	readYAML(t, filepath.Join(base, "configmap.yaml"), &configMap)
+	if configMap.Data["LOG_LEVEL"] != "info" {
+		t.Errorf("unexpected ConfigMap data: %v", configMap.Data)
+	}
+
+	var sealed struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			EncryptedData map[string]string `yaml:"encryptedData"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(base, "sealed-secret.yaml"), &sealed)
+	if sealed.Kind != "SealedSecret" || len(sealed.Spec.EncryptedData) != 1 {
+		t.Errorf("unexpected SealedSecret: %+v", sealed)
+	}
+	if content, _ := os.ReadFile(filepath.Join(base, "sealed-secret.yaml")); strings.Contains(string(content), "t0ken") {
+		t.Error("expected the SealedSecret to leave the secret values out")
+	}
+
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						EnvFrom []map[string]map[string]string `yaml:"envFrom"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	readYAML(t, filepath.Join(base, "deployment.yaml"), &deployment)
+	envFrom := deployment.Spec.Template.Spec.Containers[0].EnvFrom
+	if len(envFrom) != 2 || envFrom[0]["configMapRef"]["name"] != "api-config" || envFrom[1]["secretRef"]["name"] != "api-secret" {
+		t.Errorf("unexpected envFrom: %v", envFrom)
+	}
+
+	var kustomization struct {
+		Resources []string `yaml:"resources"`
+	}
+	readYAML(t, filepath.Join(base, "kustomization.yaml"), &kustomization)
+	if !reflect.DeepEqual(kustomization.Resources, []string{"deployment.yaml", "serviceaccount.yaml", "configmap.yaml", "sealed-secret.yaml"}) {
+		t.Errorf("unexpected base resources: %v", kustomization.Resources)
+	}
+
+	generator.Helm = true
+	if err := generator.Generate(dir); err == nil {
+		t.Error("expected an error for sealed secrets in a Helm chart")
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	for quantity, want := range map[string]float64{"500m": 0.5, "1": 1, "1.5": 1.5, "256Mi": 256 << 20, "1Gi": 1 << 30, "2k": 2000, "1e3": 1000, "1Ei": 1 << 60} {
+		got, err := ParseQuantity(quantity)
+		if err != nil || got != want {
+			t.Errorf("expected %s to be %v, got %v %v", quantity, want, got, err)
+		}
+	}
+	for _, quantity := range []string{"", "-1", "1.5.0", "256MB", "m", "1 Gi", "0x10"} {
+		if _, err := ParseQuantity(quantity); err == nil {
+			t.Errorf("expected %q to be invalid", quantity)
+		}
+	}
+}
+
+func TestImage_Validate(t *testing.T) {
+	valid := []Image{
+		{Repository: "api", Tag: "latest"},
+		{Registry: "localhost:5000", Repository: "acme/api", Tag: "v1.2.0-rc.1"},
+		{Registry: "ghcr.io/acme", Repository: "api", Digest: "sha256:" + strings.Repeat("0", 64)},
+	}
+	for _, image := range valid {
+		if err := image.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", image, err)
+		}
+	}
+	invalid := []Image{
+		{Repository: "Acme/API", Tag: "latest"},
+		{Repository: "api", Tag: ""},
+		{Repository: "api", Tag: "-dev"},
+		{Registry: "https://ghcr.io", Repository: "api", Tag: "latest"},
+		{Repository: "api", Digest: "sha256:abc"},
+	}
+	for _, image := range invalid {
+		if err := image.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", image)
+		}
+	}
+
+	if policy, err := ParsePullPolicy("ifnotpresent"); err != nil || policy != "IfNotPresent" {
+		t.Errorf("expected IfNotPresent, got %q %v", policy, err)
+	}
+	if _, err := ParseSize("huge"); err == nil {
+		t.Error("expected an error for an unknown size")
+	}
+}
+
+func TestGenerate_Kustomize(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "acme/api", "default", 8080, true, true)
+	generator.Kustomize = true
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var base struct {
+		Resources []string `yaml:"resources"`
+	}
+	readYAML(t, filepath.Join(dir, "k8s", "base", "kustomization.yaml"), &base)
+	if !reflect.DeepEqual(base.Resources, []string{"deployment.yaml", "serviceaccount.yaml", "service.yaml", "ingress.yaml"}) {
+		t.Errorf("unexpected base resources: %v", base.Resources)
+	}
+
+	type overlay struct {
+		Resources []string `yaml:"resources"`
+		Replicas  []struct {
+			Name  string `yaml:"name"`
+			Count int    `yaml:"count"`
+		} `yaml:"replicas"`
+		Images []struct {
+			Name   string `yaml:"name"`
+			NewTag string `yaml:"newTag"`
+		} `yaml:"images"`
+		Patches []struct {
+			Target struct {
+				Kind string `yaml:"kind"`
+			} `yaml:"target"`
+			Patch string `yaml:"patch"`
+		} `yaml:"patches"`
+	}
+	replicas := map[string]int{"dev": 1, "staging": 2, "prod": 3}
+	for env, count := range replicas {
+		var o overlay
+		readYAML(t, filepath.Join(dir, "k8s", "overlays", env, "kustomization.yaml"), &o)
+		if !reflect.DeepEqual(o.Resources, []string{"../../base"}) {
+			t.Errorf("%s: unexpected resources %v", env, o.Resources)
+		}
+		if len(o.Replicas) != 1 || o.Replicas[0].Name != "api" || o.Replicas[0].Count != count {
+			t.Errorf("%s: unexpected replicas %+v", env, o.Replicas)
+		}
+		if len(o.Images) != 1 || o.Images[0].Name != "acme/api" || o.Images[0].NewTag != env {
+			t.Errorf("%s: unexpected images %+v", env, o.Images)
+		}
+		if len(o.Patches) != 2 || o.Patches[1].Target.Kind != "Ingress" {
+			t.Fatalf("%s: expected a deployment and an ingress patch, got %+v", env, o.Patches)
+		}
+
+		// The inline patches are JSON patches
+		var ops []struct {
+			Op    string      `yaml:"op"`
+			Path  string      `yaml:"path"`
+			Value interface{} `yaml:"value"`
+		}
+		if err := yaml.Unmarshal([]byte(o.Patches[1].Patch), &ops); err != nil {
+			t.Fatalf("%s: expected a valid ingress patch, got %v", env, err)
+		}
+		host := generator.NewEnvironment(env).Hosts[0]
+		if len(ops) != 1 || ops[0].Path != "/spec/rules/0/host" || ops[0].Value != host {
+			t.Errorf("%s: unexpected ingress patch %+v", env, ops)
+		}
+	}
+}
+
+func TestGenerate_KustomizeDigest(t *testing.T) {
+	dir := t.TempDir()
+
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	generator := NewManifestGenerator("api", "shop", "acme/api", "default", 8080, false, false)
+	generator.Kustomize = true
+	generator.Image.Digest = digest
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "k8s", "base", "deployment.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "image: acme/api@"+digest) {
+		t.Errorf("expected the base image to be pinned, got:\n%s", content)
+	}
+
+	// An images entry with a newTag would replace the digest
+	for _, env := range DefaultEnvironments {
+		var overlay map[string]interface{}
+		readYAML(t, filepath.Join(dir, "k8s", "overlays", env, "kustomization.yaml"), &overlay)
+		if images, ok := overlay["images"]; ok {
+			t.Errorf("%s: expected the overlay to keep the digest, got images %v", env, images)
+		}
+	}
+}
+
+func TestGenerate_Helm(t *testing.T) {
+	dir := t.TempDir()
+
+	generator := NewManifestGenerator("api", "shop", "acme/api", "default", 3000, true, false)
+	generator.Helm = true
+	if err := generator.Generate(dir); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	chartDir := filepath.Join(dir, "charts", "api")
+
+	var chart struct {
+		APIVersion string `yaml:"apiVersion"`
+		Name       string `yaml:"name"`
+		AppVersion string `yaml:"appVersion"`
+	}
+	readYAML(t, filepath.Join(chartDir, "Chart.yaml"), &chart)
+	if chart.APIVersion != "v2" || chart.Name != "api" || chart.AppVersion != "latest" {
+		t.Errorf("unexpected Chart.yaml: %+v", chart)
+	}
+
+	var values struct {
+		ReplicaCount int `yaml:"replicaCount"`
+		Image        struct {
+			Repository string `yaml:"repository"`
+		} `yaml:"image"`
+		ContainerPort int `yaml:"containerPort"`
+		Service       struct {
+			Enabled bool `yaml:"enabled"`
+			Port    int  `yaml:"port"`
+		} `yaml:"service"`
+		Ingress struct {
+			Enabled bool `yaml:"enabled"`
+			Hosts   []struct {
+				Host string `yaml:"host"`
+			} `yaml:"hosts"`
+		} `yaml:"ingress"`
+	}
+	readYAML(t, filepath.Join(chartDir, "values.yaml"), &values)
+	if values.ReplicaCount != 1 || values.Image.Repository != "acme/api" || values.ContainerPort != 3000 {
+		t.Errorf("unexpected values: %+v", values)
+	}
+	if !values.Service.Enabled || values.Service.Port != 80 || values.Ingress.Enabled {
+		t.Errorf("expected the service only to be enabled, got %+v %+v", values.Service, values.Ingress)
+	}
+	if len(values.Ingress.Hosts) != 1 || values.Ingress.Hosts[0].Host != "api.example.com" {
+		t.Errorf("unexpected ingress hosts: %+v", values.Ingress.Hosts)
+	}
+
+	deployment, err := os.ReadFile(filepath.Join(chartDir, "templates", "deployment.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The container is named like in the plain manifests for kubectl logs -c and patches
+	for _, snippet := range []string{
+		`{{ include "api.fullname" . }}`,
+		"replicas: {{ .Values.replicaCount }}",
+		"{{- toYaml .Values.resources | nindent 10 }}",
+		"  - name: " + generator.ContainerName + "\n",
+	} {
+		if !strings.Contains(string(deployment), snippet) {
+			t.Errorf("expected the deployment template to contain %q, got:\n%s", snippet, deployment)
+		}
+	}
+
+	for name := range generator.ChartFiles() {
+		if _, err := os.Stat(filepath.Join(chartDir, name)); err != nil {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "k8s")); err == nil {
+		t.Error("expected no manifests next to the chart")
+	}
+}
+
+func TestInferSettings(t *testing.T) {
+	dir := t.TempDir()
+	project := &projects.ProjectInfo{Dir: dir, Name: "github.com/acme/Shop_API/v2", Type: projects.GoLang, GoType: projects.WebGo}
+
+	settings, err := InferSettings(project)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if settings.AppName != "shop-api" || settings.Port != 8080 || settings.Probes.Liveness == nil || settings.RunAsUser != DefaultUser {
+		t.Errorf("unexpected settings for a Go web project: %+v", settings)
+	}
+	if settings.Image != "shop-api" {
+		t.Errorf("expected the image to be named after the app without a Dockerfile, got %q", settings.Image)
+	}
+
+	files := map[string]string{
+		".env":       "PORT=9090\nAPP_ENV=development\n",
+		"Dockerfile": "FROM scratch\nLABEL org.opencontainers.image.title=\"shop\" \\\n      org.opencontainers.image.source=\"https://github.com/acme/shop\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	settings, err = InferSettings(project)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if settings.Port != 9090 || settings.Image != "shop" {
+		t.Errorf("expected the port of .env and the image of the Dockerfile, got %+v", settings)
+	}
+
+	node, err := InferSettings(&projects.ProjectInfo{Dir: t.TempDir(), Name: "web", Type: projects.NodeJS, NodeType: projects.Express})
+	if err != nil || node.Port != 3000 || node.RunAsUser != 1000 {
+		t.Errorf("expected an Express project to listen on 3000 as the node user, got %+v, %v", node, err)
+	}
+
+	for name, want := range map[string]string{"@acme/web": "web", "my.app": "my-app", "example.com/api/v10": "api", "": ""} {
+		if got := AppName(name); got != want {
+			t.Errorf("expected app name %q for %q, got %q", want, name, got)
+		}
+	}
+}
+
+func TestValidateEnvironment(t *testing.T) {
+	for _, name := range []string{"dev", "qa-1"} {
+		if err := ValidateEnvironment(name); err != nil {
+			t.Errorf("expected %s to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Prod", "-dev", "dev/eu"} {
+		if err := ValidateEnvironment(name); err == nil {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
+
+// readYAML decodes a generated YAML file
+func readYAML(t *testing.T, path string, out interface{}) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(content, out); err != nil {
+		t.Fatalf("expected valid YAML in %s, got %v:\n%s", path, err, content)
+	}
+}
@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// ProjectSettings are the manifest settings inferred from a project, so the flags
+// only have to override them
+type ProjectSettings struct {
+	// AppName is the module or package name turned into a DNS label
+	AppName string
+	// Image is the image repository, the title label of the Dockerfile or the app
+	// name when the project has none, so the image and the Deployment agree
+	Image string
+	// Port is the PORT of the project's .env or the port of its template, 0 when the
+	// project serves no traffic
+	Port int
+	// Probes check the health endpoint of the project template
+	Probes Probes
+	// RunAsUser is the numeric user of the image built by the docker command
+	RunAsUser int
+}
+
+// InferSettings reads the manifest settings of a project from its go.mod or
+// package.json, its .env and its Dockerfile
+func InferSettings(project *projects.ProjectInfo) (ProjectSettings, error) {
+	settings := ProjectSettings{
+		AppName:   AppName(project.Name),
+		Image:     docker.ImageTitle(project.Dir),
+		Port:      docker.DefaultPort(project),
+		Probes:    DefaultProbes(project),
+		RunAsUser: docker.DefaultUser(project),
+	}
+	if settings.AppName == "" {
+		settings.AppName = AppName(filepath.Base(project.Dir))
+	}
+	if settings.Image == "" {
+		settings.Image = settings.AppName
+	}
+
+	vars, err := utils.ReadEnvFile(filepath.Join(project.Dir, ".env"))
+	if err != nil {
+		return ProjectSettings{}, err
+	}
+	for _, v := range vars {
+		if port, err := strconv.Atoi(v.Value); v.Key == "PORT" && err == nil && port > 0 && port <= 65535 {
+			settings.Port = port
+		}
+	}
+	return settings, nil
+}
+
+// invalidNameChars matches the characters not allowed in Kubernetes resource names
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// goMajorVersion matches the major version suffix of Go module paths
+var goMajorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// AppName turns a Go module path or an npm package name into a DNS label usable as
+// the name of the resources: github.com/acme/api/v2 and @acme/api both become api.
+// It returns an empty string when nothing usable is left.
+func AppName(name string) string {
+	name = strings.TrimSuffix(name, "/")
+	if dir, base := path.Split(name); goMajorVersion.MatchString(base) && dir != "" {
+		name = strings.TrimSuffix(dir, "/")
+	}
+	name = strings.ToLower(path.Base(name))
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the workload resource running the container
+type Kind string
+
+const (
+	Deployment  Kind = "Deployment"
+	StatefulSet Kind = "StatefulSet"
+	Job         Kind = "Job"
+	CronJob     Kind = "CronJob"
+	DaemonSet   Kind = "DaemonSet"
+)
+
+// Kinds are the supported workload kinds
+var Kinds = []Kind{Deployment, StatefulSet, Job, CronJob, DaemonSet}
+
+// ParseKind validates a workload kind, ignoring case
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if strings.EqualFold(name, string(kind)) {
+			return kind, nil
+		}
+	}
+	names := make([]string, len(Kinds))
+	for i, kind := range Kinds {
+		names[i] = string(kind)
+	}
+	return "", fmt.Errorf("unsupported workload kind %q, use %s", name, strings.Join(names, ", "))
+}
+
+// APIVersion returns the API group version of the kind
+func (k Kind) APIVersion() string {
+	if k == Job || k == CronJob {
+		return "batch/v1"
+	}
+	return "apps/v1"
+}
+
+// File returns the file name of the workload manifest, like deployment.yaml
+func (k Kind) File() string {
+	return strings.ToLower(string(k)) + ".yaml"
+}
+
+// Replicated reports whether the kind runs a replica count, which autoscalers and
+// disruption budgets manage
+func (k Kind) Replicated() bool {
+	return k == Deployment || k == StatefulSet
+}
+
+// Serving reports whether the pods keep running and serve traffic, unlike Jobs and
+// CronJobs which run to completion
+func (k Kind) Serving() bool {
+	return k != Job && k != CronJob
+}
+
+// PodIndent returns the indentation of the pod template in the manifest, deeper
+// for CronJobs which nest it in a job template
+func (k Kind) PodIndent() int {
+	if k == CronJob {
+		return 8
+	}
+	return 4
+}
+
+// PodSpecPath returns the JSON pointer of the pod spec in the manifest
+func (k Kind) PodSpecPath() string {
+	if k == CronJob {
+		return "/spec/jobTemplate/spec/template/spec"
+	}
+	return "/spec/template/spec"
+}
+
+// Storage is the persistent volume claimed by each pod of a StatefulSet
+type Storage struct {
+	Size      string
+	MountPath string
+	// StorageClass selects the provisioner, the cluster default when empty
+	StorageClass string
+}
+
+// Validate checks the volume size and the mount path
+func (s Storage) Validate() error {
+	size, err := ParseQuantity(s.Size)
+	if err != nil {
+		return fmt.Errorf("invalid storage size: %v", err)
+	}
+	if size == 0 {
+		return fmt.Errorf("invalid storage size %q, the volume cannot be empty", s.Size)
+	}
+	if !strings.HasPrefix(s.MountPath, "/") {
+		return fmt.Errorf("invalid mount path %q, it must be absolute", s.MountPath)
+	}
+	return nil
+}
+
+// ConcurrencyPolicies are the policies of a CronJob whose previous run is still active
+var ConcurrencyPolicies = []string{"Allow", "Forbid", "Replace"}
+
+// ParseConcurrencyPolicy validates a CronJob concurrency policy, ignoring case
+func ParseConcurrencyPolicy(name string) (string, error) {
+	for _, policy := range ConcurrencyPolicies {
+		if strings.EqualFold(name, policy) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported concurrency policy %q, use %s", name, strings.Join(ConcurrencyPolicies, ", "))
+}
+
+// Schedule configures when a CronJob runs
+type Schedule struct {
+	// Cron is a five field cron expression or a macro like @hourly
+	Cron              string
+	ConcurrencyPolicy string
+}
+
+// cronMacros are the schedule macros supported by CronJobs
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// Validate checks the shape of the cron expression and the concurrency policy, the
+// API server validates the fields themselves
+func (s Schedule) Validate() error {
+	if s.Cron == "" {
+		return fmt.Errorf("a CronJob needs a schedule like \"*/15 * * * *\" or @hourly")
+	}
+	if strings.HasPrefix(s.Cron, "@") {
+		valid := false
+		for _, macro := range cronMacros {
+			valid = valid || s.Cron == macro
+		}
+		if !valid {
+			return fmt.Errorf("unsupported schedule %q, use %s", s.Cron, strings.Join(cronMacros, ", "))
+		}
+	} else if len(strings.Fields(s.Cron)) != 5 {
+		return fmt.Errorf("invalid schedule %q, use five fields: minute hour day month weekday", s.Cron)
+	}
+	_, err := ParseConcurrencyPolicy(s.ConcurrencyPolicy)
+	return err
+}
+
+// HeadlessServiceName returns the name of the headless Service giving the pods of a
+// StatefulSet their stable network identity
+func (g *ManifestGenerator) HeadlessServiceName() string {
+	return g.AppName + "-headless"
+}
@@ -0,0 +1,167 @@
+package k8s
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+)
+
+// ProbeType is the kind of check a probe runs against the container
+type ProbeType string
+
+const (
+	HTTPProbe ProbeType = "http"
+	TCPProbe  ProbeType = "tcp"
+	ExecProbe ProbeType = "exec"
+	GRPCProbe ProbeType = "grpc"
+)
+
+// Probe is a liveness, readiness or startup probe of the container
+type Probe struct {
+	Type ProbeType
+	// Path is the request path of HTTP probes
+	Path string
+	// Port is the checked port, the container port named http when zero. gRPC
+	// probes need a port number.
+	Port int
+	// Command is the command run by exec probes
+	Command []string
+	// Service is the optional service name of gRPC health checks
+	Service string
+
+	InitialDelaySeconds int
+	PeriodSeconds       int
+	TimeoutSeconds      int
+	FailureThreshold    int
+}
+
+// ParseProbe parses a probe flag: http:/path, tcp[:port], grpc[:port[:service]] or
+// exec:command args. It returns nil for none.
+func ParseProbe(spec string) (*Probe, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch ProbeType(strings.ToLower(kind)) {
+	case "none":
+		return nil, nil
+	case HTTPProbe:
+		if !strings.HasPrefix(arg, "/") {
+			return nil, fmt.Errorf("invalid probe %q, HTTP probes need a path like http:/healthz", spec)
+		}
+		return &Probe{Type: HTTPProbe, Path: arg}, nil
+	case TCPProbe:
+		port, err := parseProbePort(spec, arg)
+		if err != nil {
+			return nil, err
+		}
+		return &Probe{Type: TCPProbe, Port: port}, nil
+	case GRPCProbe:
+		portArg, service, _ := strings.Cut(arg, ":")
+		port, err := parseProbePort(spec, portArg)
+		if err != nil {
+			return nil, err
+		}
+		return &Probe{Type: GRPCProbe, Port: port, Service: service}, nil
+	case ExecProbe:
+		command := strings.Fields(arg)
+		if len(command) == 0 {
+			return nil, fmt.Errorf("invalid probe %q, exec probes need a command like exec:cat /tmp/healthy", spec)
+		}
+		return &Probe{Type: ExecProbe, Command: command}, nil
+	default:
+		return nil, fmt.Errorf("unsupported probe %q, use http:/path, tcp[:port], grpc[:port[:service]], exec:command or none", spec)
+	}
+}
+
+// parseProbePort parses the optional port of a probe flag
+func parseProbePort(spec string, port string) (int, error) {
+	if port == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 1 || number > 65535 {
+		return 0, fmt.Errorf("invalid probe %q, %q is not a valid port", spec, port)
+	}
+	return number, nil
+}
+
+// Validate checks that the probe is complete and its timings are not negative
+func (p *Probe) Validate() error {
+	switch p.Type {
+	case HTTPProbe:
+		if !strings.HasPrefix(p.Path, "/") {
+			return fmt.Errorf("HTTP probe path %q must start with /", p.Path)
+		}
+	case ExecProbe:
+		if len(p.Command) == 0 {
+			return fmt.Errorf("exec probes need a command")
+		}
+	case GRPCProbe:
+		if p.Port == 0 {
+			return fmt.Errorf("gRPC probes need a port number")
+		}
+	case TCPProbe:
+	default:
+		return fmt.Errorf("unsupported probe type %q", p.Type)
+	}
+	if p.Port < 0 || p.Port > 65535 {
+		return fmt.Errorf("invalid probe port %d", p.Port)
+	}
+	for _, timing := range []int{p.InitialDelaySeconds, p.PeriodSeconds, p.TimeoutSeconds, p.FailureThreshold} {
+		if timing < 0 {
+			return fmt.Errorf("probe timings cannot be negative")
+		}
+	}
+	return nil
+}
+
+// Probes are the liveness, readiness and startup probes of the container, a nil
+// probe is left out of the manifests
+type Probes struct {
+	Liveness  *Probe
+	Readiness *Probe
+	Startup   *Probe
+}
+
+// NewProbes returns the three probes running the same check. The startup probe
+// gives the container up to two and a half minutes to start, after which the
+// liveness probe restarts it when it stops answering.
+func NewProbes(check Probe) Probes {
+	liveness, readiness, startup := check, check, check
+	liveness.PeriodSeconds, liveness.FailureThreshold = 10, 3
+	readiness.PeriodSeconds, readiness.FailureThreshold = 5, 3
+	startup.PeriodSeconds, startup.FailureThreshold = 5, 30
+	return Probes{Liveness: &liveness, Readiness: &readiness, Startup: &startup}
+}
+
+// DefaultProbes returns the probes matching the health endpoint of a project created
+// by initiator: /healthz for Go web, Express and Fastify projects and / for the other
+// web frameworks. Projects that do not serve HTTP get no probes.
+func DefaultProbes(project *projects.ProjectInfo) Probes {
+	switch {
+	case project.Type == projects.GoLang && project.GoType == projects.WebGo,
+		project.NodeType == projects.Express,
+		project.NodeType == projects.Fastify:
+		return NewProbes(Probe{Type: HTTPProbe, Path: "/healthz"})
+	case project.Type == projects.NodeJS && project.NodeType != projects.TypeScriptBasic:
+		return NewProbes(Probe{Type: HTTPProbe, Path: "/"})
+	}
+	return Probes{}
+}
+
+// List returns the probes by their key in the container spec, skipping nil ones
+func (p Probes) List() []NamedProbe {
+	var list []NamedProbe
+	for _, probe := range []NamedProbe{{"livenessProbe", p.Liveness}, {"readinessProbe", p.Readiness}, {"startupProbe", p.Startup}} {
+		if probe.Probe != nil {
+			list = append(list, probe)
+		}
+	}
+	return list
+}
+
+// NamedProbe is a probe with its key in the container spec
+type NamedProbe struct {
+	Key string
+	*Probe
+}
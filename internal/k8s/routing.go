@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Ingress configures how the Service is exposed outside the cluster, through an
+// Ingress or a Gateway API HTTPRoute
+type Ingress struct {
+	// Hosts are the host names routed to the Service, the Kustomize overlays prefix
+	// each of them with the environment, see EnvironmentHosts
+	Hosts []string
+	// Path is the path prefix routed to the Service
+	Path string
+	// ClassName selects the ingress controller, the cluster default when empty
+	ClassName   string
+	Annotations map[string]string
+	// TLSSecret is the Secret holding the certificate of the hosts, no TLS when empty
+	TLSSecret string
+	// ClusterIssuer is the cert-manager ClusterIssuer issuing the certificate into
+	// TLSSecret
+	ClusterIssuer string
+}
+
+// Gateway is the Gateway API Gateway an HTTPRoute attaches to
+type Gateway struct {
+	Name string
+	// Namespace of the Gateway, the namespace of the route when empty
+	Namespace string
+	// SectionName selects a listener of the Gateway, all of them when empty
+	SectionName string
+}
+
+// ParseGateway parses a Gateway reference written as name or namespace/name
+func ParseGateway(reference string) (*Gateway, error) {
+	gateway := &Gateway{Name: reference}
+	if namespace, name, ok := strings.Cut(reference, "/"); ok {
+		gateway = &Gateway{Name: name, Namespace: namespace}
+	}
+	for _, name := range []string{gateway.Name, gateway.Namespace} {
+		if name != "" && !dnsLabel.MatchString(name) {
+			return nil, fmt.Errorf("invalid gateway %q, use name or namespace/name", reference)
+		}
+	}
+	if gateway.Name == "" {
+		return nil, fmt.Errorf("invalid gateway %q, use name or namespace/name", reference)
+	}
+	return gateway, nil
+}
+
+var (
+	dnsLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+	hostName = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+)
+
+// Validate checks the host names, the path and the TLS settings
+func (i Ingress) Validate() error {
+	if len(i.Hosts) == 0 {
+		return fmt.Errorf("the ingress needs at least one host")
+	}
+	for _, host := range i.Hosts {
+		if !hostName.MatchString(host) {
+			return fmt.Errorf("invalid ingress host %q, use a lowercase DNS name like api.example.com", host)
+		}
+	}
+	if !strings.HasPrefix(i.Path, "/") {
+		return fmt.Errorf("invalid ingress path %q, it must start with /", i.Path)
+	}
+	if i.ClusterIssuer != "" && i.TLSSecret == "" {
+		return fmt.Errorf("the cert-manager issuer needs a TLS secret to store the certificate")
+	}
+	return nil
+}
+
+// EnvironmentHosts returns the hosts of an environment, each one prefixed with the
+// environment as a subdomain: acme.com becomes staging.acme.com and *.acme.com
+// becomes *.staging.acme.com, prod keeps them unchanged
+func (i Ingress) EnvironmentHosts(env string) []string {
+	hosts := make([]string, len(i.Hosts))
+	for n, host := range i.Hosts {
+		switch {
+		case env == "prod":
+			hosts[n] = host
+		case strings.HasPrefix(host, "*."):
+			hosts[n] = "*." + env + "." + strings.TrimPrefix(host, "*.")
+		default:
+			hosts[n] = env + "." + host
+		}
+	}
+	return hosts
+}
+
+// AllAnnotations returns the annotations of the Ingress, including the cert-manager
+// issuer
+func (i Ingress) AllAnnotations() map[string]string {
+	annotations := map[string]string{}
+	for key, value := range i.Annotations {
+		annotations[key] = value
+	}
+	if i.ClusterIssuer != "" {
+		annotations["cert-manager.io/cluster-issuer"] = i.ClusterIssuer
+	}
+	return annotations
+}
@@ -0,0 +1,81 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Autoscaling configures a HorizontalPodAutoscaler scaling the Deployment on the
+// average CPU and memory utilization of its pods, relative to their requests
+type Autoscaling struct {
+	MinReplicas int
+	MaxReplicas int
+	// CPUUtilization is the target CPU utilization in percent, no CPU metric when zero
+	CPUUtilization int
+	// MemoryUtilization is the target memory utilization in percent, no memory
+	// metric when zero
+	MemoryUtilization int
+}
+
+// Validate checks the replica bounds and that at least one metric is set
+func (a *Autoscaling) Validate() error {
+	if a.MinReplicas < 1 {
+		return fmt.Errorf("invalid autoscaling minimum %d, use 1 or more replicas", a.MinReplicas)
+	}
+	if a.MaxReplicas < a.MinReplicas {
+		return fmt.Errorf("autoscaling maximum %d is below the minimum %d", a.MaxReplicas, a.MinReplicas)
+	}
+	if a.CPUUtilization == 0 && a.MemoryUtilization == 0 {
+		return fmt.Errorf("autoscaling needs a CPU or memory utilization target")
+	}
+	for _, target := range []int{a.CPUUtilization, a.MemoryUtilization} {
+		if target < 0 || target > 100 {
+			return fmt.Errorf("invalid utilization target %d%%, use 1 to 100", target)
+		}
+	}
+	return nil
+}
+
+// MaxReplicasFor returns the maximum replicas of an environment running at least
+// minReplicas pods
+func (a *Autoscaling) MaxReplicasFor(minReplicas int) int {
+	if minReplicas > a.MaxReplicas {
+		return minReplicas
+	}
+	return a.MaxReplicas
+}
+
+// DisruptionBudget configures a PodDisruptionBudget limiting how many pods voluntary
+// disruptions like node drains can take down at once. Exactly one of MinAvailable
+// and MaxUnavailable is set, each a number of pods or a percentage like 50%.
+type DisruptionBudget struct {
+	MinAvailable   string
+	MaxUnavailable string
+}
+
+// disruptionValue matches a number of pods or a percentage
+var disruptionValue = regexp.MustCompile(`^[0-9]+%?$`)
+
+// Validate checks the budget against the number of replicas the Deployment runs at
+// least, since a minimum reaching it would block every node drain
+func (b *DisruptionBudget) Validate(replicas int) error {
+	if (b.MinAvailable == "") == (b.MaxUnavailable == "") {
+		return fmt.Errorf("a disruption budget needs either a minimum available or a maximum unavailable")
+	}
+	value := b.MinAvailable + b.MaxUnavailable
+	if !disruptionValue.MatchString(value) {
+		return fmt.Errorf("invalid disruption budget %q, use a number of pods or a percentage like 50%%", value)
+	}
+	if strings.HasSuffix(value, "%") {
+		if percent, _ := strconv.Atoi(strings.TrimSuffix(value, "%")); percent > 100 {
+			return fmt.Errorf("invalid disruption budget %q, use at most 100%%", value)
+		}
+		return nil
+	}
+	if pods, _ := strconv.Atoi(value); b.MinAvailable != "" && pods >= replicas {
+		return fmt.Errorf("keeping %d pods available blocks node drains with %d replicas, lower --min-available or add replicas", pods, replicas)
+	}
+	return nil
+}
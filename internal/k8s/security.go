@@ -0,0 +1,46 @@
+package k8s
+
+import "fmt"
+
+// Security is the hardened profile of the pods, meeting the Restricted Pod Security
+// Standard: a non-root user, a read-only root filesystem with a writable /tmp, no
+// capabilities or privilege escalation, the RuntimeDefault seccomp profile and a
+// dedicated ServiceAccount without an API token
+type Security struct {
+	// RunAsUser is the numeric user of the containers, runAsNonRoot cannot check
+	// images naming their user
+	RunAsUser int
+}
+
+// DefaultUser is the user of the distroless nonroot images
+const DefaultUser = 65532
+
+// Validate checks that the pods do not run as root
+func (s *Security) Validate() error {
+	if s.RunAsUser <= 0 {
+		return fmt.Errorf("invalid user %d, the hardened profile runs as a non-root user", s.RunAsUser)
+	}
+	return nil
+}
+
+// NetworkPolicy only admits the traffic of the ingress controller to the pods
+type NetworkPolicy struct {
+	// IngressNamespace is the namespace of the ingress controller or Gateway
+	IngressNamespace string
+}
+
+// DefaultIngressNamespace is the namespace of the ingress-nginx controller
+const DefaultIngressNamespace = "ingress-nginx"
+
+// Validate checks the namespace of the ingress controller
+func (p *NetworkPolicy) Validate() error {
+	if !dnsLabel.MatchString(p.IngressNamespace) {
+		return fmt.Errorf("invalid ingress namespace %q, use a lowercase DNS label", p.IngressNamespace)
+	}
+	return nil
+}
+
+// ServiceAccountName returns the name of the dedicated ServiceAccount of the pods
+func (g *ManifestGenerator) ServiceAccountName() string {
+	return g.AppName
+}
@@ -1,34 +1,303 @@
 package k8s
 
-const deploymentTemplate = `apiVersion: apps/v1
-kind: Deployment
+// workloadTemplate renders the Deployment, StatefulSet, Job, CronJob or DaemonSet
+// running the pod of podTemplate
+const workloadTemplate = `apiVersion: {{.Kind.APIVersion}}
+kind: {{.Kind}}
 metadata:
   name: {{.AppName}}
   namespace: {{.Namespace}}
   labels:
     app: {{.ProjectName}}
 spec:
-  replicas: 1
+{{- if eq .Kind "CronJob"}}
+  schedule: {{quote .Schedule.Cron}}
+  concurrencyPolicy: {{.Schedule.ConcurrencyPolicy}}
+  jobTemplate:
+    spec:
+      template:
+{{- else}}
+{{- if not .Kind.Replicated}}
+{{- else if .Autoscaling}}
+  # The replicas are managed by the HorizontalPodAutoscaler in hpa.yaml
+{{- else}}
+  replicas: {{.Replicas}}
+{{- end}}
+{{- if eq .Kind "StatefulSet"}}
+  serviceName: {{.HeadlessServiceName}}
+{{- end}}
+{{- if ne .Kind "Job"}}
   selector:
     matchLabels:
       app: {{.ProjectName}}
+{{- end}}
+  template:
+{{- end}}
+{{include "podTemplate" . | indent .Kind.PodIndent}}
+{{- if eq .Kind "StatefulSet"}}
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes:
+      - ReadWriteOnce
+{{- with .Storage.StorageClass}}
+      storageClassName: {{.}}
+{{- end}}
+      resources:
+        requests:
+          storage: {{.Storage.Size}}
+{{- end}}
+`
+
+// podTemplate renders the pod template shared by every workload kind. Jobs and
+// CronJobs run to completion, so their pods expose no port and take no probes.
+const podTemplate = `{{define "podTemplate"}}
+metadata:
+  labels:
+    app: {{.ProjectName}}
+spec:
+{{- with .Security}}
+  serviceAccountName: {{$.ServiceAccountName}}
+  automountServiceAccountToken: false
+  securityContext:
+    runAsNonRoot: true
+    runAsUser: {{.RunAsUser}}
+    runAsGroup: {{.RunAsUser}}
+    fsGroup: {{.RunAsUser}}
+    seccompProfile:
+      type: RuntimeDefault
+{{- end}}
+{{- if not .Kind.Serving}}
+  restartPolicy: OnFailure
+{{- end}}
+  containers:
+  - name: {{.ContainerName}}
+    image: {{.Image.Reference}}
+{{- if .PullPolicy}}
+    imagePullPolicy: {{.PullPolicy}}
+{{- end}}
+{{- if .Kind.Serving}}
+    ports:
+    - name: http
+      containerPort: {{.Port}}
+{{- end}}
+{{- if or .Config .Secrets}}
+    envFrom:
+{{- if .Config}}
+    - configMapRef:
+        name: {{.ConfigMapName}}
+{{- end}}
+{{- if .Secrets}}
+    - secretRef:
+        name: {{.SecretName}}
+{{- end}}
+{{- end}}
+{{- range .Probes.List}}
+    {{.Key}}:
+{{include "probe" .Probe | indent 6}}
+{{- end}}
+    resources:
+      requests:
+        cpu: {{.Resources.CPURequest}}
+        memory: {{.Resources.MemoryRequest}}
+      limits:
+        cpu: {{.Resources.CPULimit}}
+        memory: {{.Resources.MemoryLimit}}
+{{- if .Security}}
+    securityContext:
+      allowPrivilegeEscalation: false
+      readOnlyRootFilesystem: true
+      capabilities:
+        drop:
+        - ALL
+{{- end}}
+{{- if or .Security (eq .Kind "StatefulSet")}}
+    volumeMounts:
+{{- if .Security}}
+    # The root filesystem is read-only, /tmp stays writable
+    - name: tmp
+      mountPath: /tmp
+{{- end}}
+{{- if eq .Kind "StatefulSet"}}
+    - name: data
+      mountPath: {{.Storage.MountPath}}
+{{- end}}
+{{- end}}
+{{- if .Security}}
+  volumes:
+  - name: tmp
+    emptyDir: {}
+{{- end}}
+{{- end}}`
+
+// probeTemplate renders the spec of a probe, it is included by the manifests and,
+// with [[ ]] delimiters, by values.yaml of the chart
+const probeTemplate = `{{define "probe"}}
+{{- if eq .Type "http"}}
+httpGet:
+  path: {{.Path}}
+  port: {{if .Port}}{{.Port}}{{else}}http{{end}}
+{{- else if eq .Type "tcp"}}
+tcpSocket:
+  port: {{if .Port}}{{.Port}}{{else}}http{{end}}
+{{- else if eq .Type "grpc"}}
+grpc:
+  port: {{.Port}}
+{{- if .Service}}
+  service: {{quote .Service}}
+{{- end}}
+{{- else if eq .Type "exec"}}
+exec:
+  command:
+{{- range .Command}}
+  - {{quote .}}
+{{- end}}
+{{- end}}
+{{- if .InitialDelaySeconds}}
+initialDelaySeconds: {{.InitialDelaySeconds}}
+{{- end}}
+{{- if .PeriodSeconds}}
+periodSeconds: {{.PeriodSeconds}}
+{{- end}}
+{{- if .TimeoutSeconds}}
+timeoutSeconds: {{.TimeoutSeconds}}
+{{- end}}
+{{- if .FailureThreshold}}
+failureThreshold: {{.FailureThreshold}}
+{{- end}}
+{{- end}}`
+
+const hpaTemplate = `apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{.AppName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: {{.Kind}}
+    name: {{.AppName}}
+  minReplicas: {{.Autoscaling.MinReplicas}}
+  maxReplicas: {{.Autoscaling.MaxReplicas}}
+  metrics:
+{{- if .Autoscaling.CPUUtilization}}
+  - type: Resource
+    resource:
+      name: cpu
+      target:
+        type: Utilization
+        averageUtilization: {{.Autoscaling.CPUUtilization}}
+{{- end}}
+{{- if .Autoscaling.MemoryUtilization}}
+  - type: Resource
+    resource:
+      name: memory
+      target:
+        type: Utilization
+        averageUtilization: {{.Autoscaling.MemoryUtilization}}
+{{- end}}
+`
+
+const pdbTemplate = `apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: {{.AppName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+spec:
+{{- if .DisruptionBudget.MinAvailable}}
+  minAvailable: {{.DisruptionBudget.MinAvailable}}
+{{- else}}
+  maxUnavailable: {{.DisruptionBudget.MaxUnavailable}}
+{{- end}}
+  selector:
+    matchLabels:
+      app: {{.ProjectName}}
+`
+
+const configMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{.ConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+data:
+{{- range .Config}}
+  {{.Key}}: {{quote .Value}}
+{{- end}}
+`
+
+const secretTemplate = `# Holds the secrets of the .env file in clear text, keep it out of version control
+# or switch to --secrets sealed or --secrets external
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{.SecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+type: Opaque
+stringData:
+{{- range .Secrets}}
+  {{.Key}}: {{quote .Value}}
+{{- end}}
+`
+
+const sealedSecretTemplate = `# Replace the placeholders with values encrypted for the cluster, e.g.
+#   echo -n "$VALUE" | kubeseal --raw --namespace {{.Namespace}} --name {{.SecretName}}
+# or regenerate the whole file with
+#   kubectl create secret generic {{.SecretName}} --namespace {{.Namespace}} --dry-run=client -o yaml \
+#    {{range .Secrets}} --from-literal={{.Key}}=...{{end}} | kubeseal --format yaml
+apiVersion: bitnami.com/v1alpha1
+kind: SealedSecret
+metadata:
+  name: {{.SecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+spec:
+  encryptedData:
+{{- range .Secrets}}
+    {{.Key}}: REPLACE_WITH_KUBESEAL_OUTPUT
+{{- end}}
   template:
     metadata:
+      name: {{.SecretName}}
+      namespace: {{.Namespace}}
       labels:
         app: {{.ProjectName}}
-    spec:
-      containers:
-      - name: {{.ContainerName}}
-        image: {{.ContainerName}}:latest
-        ports:
-        - containerPort: {{.Port}}
-        resources:
-          requests:
-            cpu: 100m
-            memory: 128Mi
-          limits:
-            cpu: 500m
-            memory: 256Mi
+    type: Opaque
+`
+
+const externalSecretTemplate = `# Reads the secrets from the {{.AppName}} entry of a secret store of the External
+# Secrets Operator, point secretStoreRef at the store holding them
+apiVersion: external-secrets.io/v1
+kind: ExternalSecret
+metadata:
+  name: {{.SecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+spec:
+  refreshInterval: 1h
+  secretStoreRef:
+    kind: SecretStore
+    name: secret-store
+  target:
+    name: {{.SecretName}}
+    creationPolicy: Owner
+  data:
+{{- range .Secrets}}
+  - secretKey: {{.Key}}
+    remoteRef:
+      key: {{$.AppName}}
+      property: {{.Key}}
+{{- end}}
 `
 
 const serviceTemplate = `apiVersion: v1
@@ -45,23 +314,796 @@ spec:
   type: ClusterIP
 `
 
+const serviceAccountTemplate = `# Identity of the pods, without an API token since the application does not call
+# the Kubernetes API
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{.ServiceAccountName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+automountServiceAccountToken: false
+`
+
+const networkPolicyTemplate = `# Only admits traffic from the ingress controller in the {{.NetworkPolicy.IngressNamespace}} namespace
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{.AppName}}
+  namespace: {{.Namespace}}
+  labels:
+    app: {{.ProjectName}}
+spec:
+  podSelector:
+    matchLabels:
+      app: {{.ProjectName}}
+  policyTypes:
+  - Ingress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: {{.NetworkPolicy.IngressNamespace}}
+    ports:
+    - port: http
+`
+
+const headlessServiceTemplate = `# Gives the pods of the StatefulSet stable DNS names like {{.AppName}}-0.{{.HeadlessServiceName}}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.HeadlessServiceName}}
+  namespace: {{.Namespace}}
+spec:
+  clusterIP: None
+  selector:
+    app: {{.ProjectName}}
+  ports:
+  - name: http
+    port: {{.Port}}
+    targetPort: http
+`
+
 const ingressTemplate = `apiVersion: networking.k8s.io/v1
 kind: Ingress
 metadata:
   name: {{.AppName}}-ingress
   namespace: {{.Namespace}}
+{{- with .Ingress.AllAnnotations}}
   annotations:
-    nginx.ingress.kubernetes.io/rewrite-target: /
+{{- range $key, $value := .}}
+    {{$key}}: {{quote $value}}
+{{- end}}
+{{- end}}
 spec:
+{{- with .Ingress.ClassName}}
+  ingressClassName: {{.}}
+{{- end}}
+{{- if .Ingress.TLSSecret}}
+  tls:
+  - hosts:
+{{- range .Ingress.Hosts}}
+    - {{quote .}}
+{{- end}}
+    secretName: {{.Ingress.TLSSecret}}
+{{- end}}
   rules:
-  - host: {{.AppName}}.example.com
+{{- range .Ingress.Hosts}}
+  - host: {{quote .}}
     http:
       paths:
-      - path: /
+      - path: {{$.Ingress.Path}}
         pathType: Prefix
         backend:
           service:
-            name: {{.AppName}}
+            name: {{$.AppName}}
             port:
               number: 80
+{{- end}}
+`
+
+const httpRouteTemplate = `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: {{.AppName}}
+  namespace: {{.Namespace}}
+spec:
+  parentRefs:
+  - name: {{.Gateway.Name}}
+{{- with .Gateway.Namespace}}
+    namespace: {{.}}
+{{- end}}
+{{- with .Gateway.SectionName}}
+    sectionName: {{.}}
+{{- end}}
+  hostnames:
+{{- range .Ingress.Hosts}}
+  - {{quote .}}
+{{- end}}
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: {{.Ingress.Path}}
+    backendRefs:
+    - name: {{.AppName}}
+      port: 80
+`
+
+const kustomizationTemplate = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+{{- range .Manifests}}
+  - {{.}}
+{{- end}}
+`
+
+const overlayTemplate = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - ../../base
+labels:
+  - pairs:
+      environment: {{.Env.Name}}
+{{- if and .Kind.Replicated (not .Autoscaling)}}
+replicas:
+  - name: {{.AppName}}
+    count: {{.Env.Replicas}}
+{{- end}}
+{{- if not .Image.Digest}}
+images:
+  - name: {{.Image.Name}}
+    newTag: {{.Env.ImageTag}}
+{{- end}}
+patches:
+  - target:
+      kind: {{.Kind}}
+      name: {{.AppName}}
+    patch: |-
+      - op: replace
+        path: {{.Kind.PodSpecPath}}/containers/0/resources
+        value:
+          requests:
+            cpu: {{.Env.Resources.CPURequest}}
+            memory: {{.Env.Resources.MemoryRequest}}
+          limits:
+            cpu: {{.Env.Resources.CPULimit}}
+            memory: {{.Env.Resources.MemoryLimit}}
+{{- if .Autoscaling}}
+  - target:
+      kind: HorizontalPodAutoscaler
+      name: {{.AppName}}
+    patch: |-
+      - op: replace
+        path: /spec/minReplicas
+        value: {{.Env.Replicas}}
+      - op: replace
+        path: /spec/maxReplicas
+        value: {{.Autoscaling.MaxReplicasFor .Env.Replicas}}
+{{- end}}
+{{- if and .WithIngress .Gateway}}
+  - target:
+      kind: HTTPRoute
+      name: {{.AppName}}
+    patch: |-
+{{- range $i, $host := .Env.Hosts}}
+      - op: replace
+        path: /spec/hostnames/{{$i}}
+        value: {{quote $host}}
+{{- end}}
+{{- else if .WithIngress}}
+  - target:
+      kind: Ingress
+      name: {{.AppName}}-ingress
+    patch: |-
+{{- range $i, $host := .Env.Hosts}}
+      - op: replace
+        path: /spec/rules/{{$i}}/host
+        value: {{quote $host}}
+{{- if $.Ingress.TLSSecret}}
+      - op: replace
+        path: /spec/tls/0/hosts/{{$i}}
+        value: {{quote $host}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+// The chart templates are rendered with [[ ]] delimiters, the {{ }} actions are left
+// for Helm. Every value hard-coded in the manifests above is read from values.yaml.
+
+const chartTemplate = `apiVersion: v2
+name: [[.AppName]]
+description: A Helm chart for [[.ProjectName]]
+type: application
+# Version of the chart, bump it on every change to the templates
+version: 0.1.0
+# Version of the application, used as the default image tag
+appVersion: "[[or .Image.Tag "latest"]]"
+`
+
+const valuesTemplate = `# Default values for [[.AppName]].
+
+[[if .Kind.Replicated -]]
+replicaCount: [[.Replicas]]
+
+[[end -]]
+image:
+  repository: [[.Image.Name]]
+  pullPolicy: [[or .PullPolicy "IfNotPresent"]]
+  # Overrides the image tag, which defaults to the chart appVersion
+  tag: ""
+  # Pins the image by digest, like sha256:<hex>, taking precedence over the tag
+  digest: "[[.Image.Digest]]"
+
+nameOverride: ""
+fullnameOverride: ""
+
+# Value of the app label selecting the pods
+project: [[.ProjectName]]
+[[- if .Kind.Serving]]
+
+containerPort: [[.Port]]
+[[- end]]
+[[- if eq .Kind "StatefulSet"]]
+
+# Volume claimed by each pod of the StatefulSet
+persistence:
+  size: [[.Storage.Size]]
+  mountPath: [[.Storage.MountPath]]
+  storageClass: "[[.Storage.StorageClass]]"
+[[- end]]
+[[- if eq .Kind "CronJob"]]
+
+# Cron schedule of the jobs, and what to do when a run is still active: Allow,
+# Forbid or Replace
+schedule: [[quote .Schedule.Cron]]
+concurrencyPolicy: [[.Schedule.ConcurrencyPolicy]]
+[[- end]]
+[[- if .Kind.Replicated]]
+
+# Scales the replicas on the average utilization of the pods, replicaCount is
+# ignored when enabled
+autoscaling:
+  enabled: [[if .Autoscaling]]true
+  minReplicas: [[.Autoscaling.MinReplicas]]
+  maxReplicas: [[.Autoscaling.MaxReplicas]]
+  targetCPUUtilizationPercentage: [[.Autoscaling.CPUUtilization]]
+  targetMemoryUtilizationPercentage: [[.Autoscaling.MemoryUtilization]][[else]]false
+  minReplicas: [[.Replicas]]
+  maxReplicas: 5
+  targetCPUUtilizationPercentage: 80
+  targetMemoryUtilizationPercentage: 0[[end]]
+
+# Limits the pods taken down at once by voluntary disruptions, set either
+# minAvailable or maxUnavailable
+podDisruptionBudget:
+  enabled: [[if .DisruptionBudget]]true
+  minAvailable: [[quote .DisruptionBudget.MinAvailable]]
+  maxUnavailable: [[quote .DisruptionBudget.MaxUnavailable]][[else]]false
+  minAvailable: ""
+  maxUnavailable: "1"[[end]]
+[[- end]]
+
+# Variables written into a ConfigMap and a Secret, loaded with envFrom
+config:[[range .Config]]
+  [[.Key]]: [[quote .Value]][[else]] {}[[end]]
+secrets:[[range .Secrets]]
+  [[.Key]]: [[quote .Value]][[else]] {}[[end]]
+
+[[- if .Kind.Serving]]
+
+# Probes of the container, set one to {} to disable it
+livenessProbe:[[with .Probes.Liveness]]
+[[include "probe" . | indent 2]][[else]] {}[[end]]
+readinessProbe:[[with .Probes.Readiness]]
+[[include "probe" . | indent 2]][[else]] {}[[end]]
+startupProbe:[[with .Probes.Startup]]
+[[include "probe" . | indent 2]][[else]] {}[[end]]
+[[- end]]
+
+resources:
+  requests:
+    cpu: [[.Resources.CPURequest]]
+    memory: [[.Resources.MemoryRequest]]
+  limits:
+    cpu: [[.Resources.CPULimit]]
+    memory: [[.Resources.MemoryLimit]]
+
+serviceAccount:
+  # Creates a dedicated ServiceAccount, named after the release unless name is set
+  create: [[if .Security]]true[[else]]false[[end]]
+  name: ""
+  # Mounts an API token into the pods, only needed to call the Kubernetes API
+  automount: [[if .Security]]false[[else]]true[[end]]
+
+# Security contexts of the pods and of their container, the defaults meet the
+# Restricted Pod Security Standard
+podSecurityContext:[[with .Security]]
+  runAsNonRoot: true
+  runAsUser: [[.RunAsUser]]
+  runAsGroup: [[.RunAsUser]]
+  fsGroup: [[.RunAsUser]]
+  seccompProfile:
+    type: RuntimeDefault[[else]] {}[[end]]
+securityContext:[[if .Security]]
+  allowPrivilegeEscalation: false
+  readOnlyRootFilesystem: true
+  capabilities:
+    drop:
+      - ALL[[else]] {}[[end]]
+[[- if .Kind.Serving]]
+
+service:
+  enabled: [[.WithService]]
+  type: ClusterIP
+  port: 80
+
+ingress:
+  enabled: [[and .WithIngress (not .Gateway)]]
+  className: "[[.Ingress.ClassName]]"
+  annotations:[[range $key, $value := .Ingress.AllAnnotations]]
+    [[$key]]: [[quote $value]][[else]] {}[[end]]
+  hosts:[[range .Ingress.Hosts]]
+    - host: [[quote .]]
+      paths:
+        - path: [[$.Ingress.Path]]
+          pathType: Prefix[[end]]
+  tls:[[if .Ingress.TLSSecret]]
+    - secretName: [[.Ingress.TLSSecret]]
+      hosts:[[range .Ingress.Hosts]]
+        - [[quote .]][[end]][[else]] [][[end]]
+
+# Routes the traffic through a Gateway API Gateway instead of an Ingress
+httpRoute:
+  enabled: [[if and .WithIngress .Gateway]]true[[else]]false[[end]]
+  parentRefs:[[with .Gateway]]
+    - name: [[.Name]][[with .Namespace]]
+      namespace: [[.]][[end]][[with .SectionName]]
+      sectionName: [[.]][[end]][[else]]
+    - name: gateway[[end]]
+  hostnames:[[range .Ingress.Hosts]]
+    - [[quote .]][[end]]
+  path: [[.Ingress.Path]]
+
+# Only admits traffic from the ingress controller or Gateway namespace
+networkPolicy:
+  enabled: [[if .NetworkPolicy]]true
+  ingressNamespace: [[.NetworkPolicy.IngressNamespace]][[else]]false
+  ingressNamespace: ingress-nginx[[end]]
+[[- end]]
+`
+
+const helmignoreTemplate = `# Patterns to ignore when building packages
+.DS_Store
+.git/
+.gitignore
+*.swp
+*.bak
+*.tmp
+*.orig
+*~
+.idea/
+.vscode/
+`
+
+const helpersTemplate = `{{/*
+Name of the chart
+*/}}
+{{- define "[[.AppName]].name" -}}
+{{- default .Chart.Name .Values.nameOverride | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Fully qualified app name, truncated to the 63 characters allowed in DNS names
+*/}}
+{{- define "[[.AppName]].fullname" -}}
+{{- if .Values.fullnameOverride }}
+{{- .Values.fullnameOverride | trunc 63 | trimSuffix "-" }}
+{{- else }}
+{{- $name := default .Chart.Name .Values.nameOverride }}
+{{- if contains $name .Release.Name }}
+{{- .Release.Name | trunc 63 | trimSuffix "-" }}
+{{- else }}
+{{- printf "%s-%s" .Release.Name $name | trunc 63 | trimSuffix "-" }}
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{/*
+Common labels
+*/}}
+{{- define "[[.AppName]].labels" -}}
+helm.sh/chart: {{ printf "%s-%s" .Chart.Name .Chart.Version | replace "+" "_" | trunc 63 | trimSuffix "-" }}
+{{ include "[[.AppName]].selectorLabels" . }}
+app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end }}
+
+{{/*
+Selector labels
+*/}}
+{{- define "[[.AppName]].selectorLabels" -}}
+app: {{ .Values.project }}
+app.kubernetes.io/name: {{ include "[[.AppName]].name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- end }}
+
+{{/*
+Name of the ServiceAccount of the pods
+*/}}
+{{- define "[[.AppName]].serviceAccountName" -}}
+{{- if .Values.serviceAccount.create }}
+{{- default (include "[[.AppName]].fullname" .) .Values.serviceAccount.name }}
+{{- else }}
+{{- default "default" .Values.serviceAccount.name }}
+{{- end }}
+{{- end }}
+`
+
+// chartWorkloadTemplate renders the workload of the chart, parsed after
+// chartPodTemplate. The Helm indentation of the pod template follows the kind.
+const chartWorkloadTemplate = `apiVersion: [[.Kind.APIVersion]]
+kind: [[.Kind]]
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+[[- if eq .Kind "CronJob"]]
+  schedule: {{ .Values.schedule | quote }}
+  concurrencyPolicy: {{ .Values.concurrencyPolicy }}
+  jobTemplate:
+    spec:
+      template:
+[[- else]]
+[[- if .Kind.Replicated]]
+  {{- if not .Values.autoscaling.enabled }}
+  replicas: {{ .Values.replicaCount }}
+  {{- end }}
+[[- end]]
+[[- if eq .Kind "StatefulSet"]]
+  serviceName: {{ include "[[.AppName]].fullname" . }}-headless
+[[- end]]
+[[- if ne .Kind "Job"]]
+  selector:
+    matchLabels:
+      {{- include "[[.AppName]].selectorLabels" . | nindent 6 }}
+[[- end]]
+  template:
+[[- end]]
+[[include "chartPodTemplate" . | indent .Kind.PodIndent]]
+[[- if eq .Kind "StatefulSet"]]
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes:
+      - ReadWriteOnce
+      {{- with .Values.persistence.storageClass }}
+      storageClassName: {{ . }}
+      {{- end }}
+      resources:
+        requests:
+          storage: {{ .Values.persistence.size }}
+[[- end]]
+`
+
+const chartPodTemplate = `[[define "chartPodTemplate"]]
+metadata:
+  labels:
+    {{- include "[[.AppName]].selectorLabels" . | nindent [[add .Kind.PodIndent 4]] }}
+spec:
+  serviceAccountName: {{ include "[[.AppName]].serviceAccountName" . }}
+  automountServiceAccountToken: {{ .Values.serviceAccount.automount }}
+  {{- with .Values.podSecurityContext }}
+  securityContext:
+    {{- toYaml . | nindent [[add .Kind.PodIndent 4]] }}
+  {{- end }}
+  [[- if not .Kind.Serving]]
+  restartPolicy: OnFailure
+  [[- end]]
+  containers:
+  - name: [[.ContainerName]]
+    {{- if .Values.image.digest }}
+    image: "{{ .Values.image.repository }}@{{ .Values.image.digest }}"
+    {{- else }}
+    image: "{{ .Values.image.repository }}:{{ .Values.image.tag | default .Chart.AppVersion }}"
+    {{- end }}
+    imagePullPolicy: {{ .Values.image.pullPolicy }}
+    [[- if .Kind.Serving]]
+    ports:
+    - name: http
+      containerPort: {{ .Values.containerPort }}
+    [[- end]]
+    {{- if or .Values.config .Values.secrets }}
+    envFrom:
+    {{- if .Values.config }}
+    - configMapRef:
+        name: {{ include "[[.AppName]].fullname" . }}-config
+    {{- end }}
+    {{- if .Values.secrets }}
+    - secretRef:
+        name: {{ include "[[.AppName]].fullname" . }}-secret
+    {{- end }}
+    {{- end }}
+    [[- if .Kind.Serving]]
+    {{- with .Values.livenessProbe }}
+    livenessProbe:
+      {{- toYaml . | nindent [[add .Kind.PodIndent 6]] }}
+    {{- end }}
+    {{- with .Values.readinessProbe }}
+    readinessProbe:
+      {{- toYaml . | nindent [[add .Kind.PodIndent 6]] }}
+    {{- end }}
+    {{- with .Values.startupProbe }}
+    startupProbe:
+      {{- toYaml . | nindent [[add .Kind.PodIndent 6]] }}
+    {{- end }}
+    [[- end]]
+    resources:
+      {{- toYaml .Values.resources | nindent [[add .Kind.PodIndent 6]] }}
+    {{- with .Values.securityContext }}
+    securityContext:
+      {{- toYaml . | nindent [[add .Kind.PodIndent 6]] }}
+    {{- end }}
+    [[- if or .Security (eq .Kind "StatefulSet")]]
+    volumeMounts:
+    [[- if .Security]]
+    # The root filesystem is read-only, /tmp stays writable
+    - name: tmp
+      mountPath: /tmp
+    [[- end]]
+    [[- if eq .Kind "StatefulSet"]]
+    - name: data
+      mountPath: {{ .Values.persistence.mountPath }}
+    [[- end]]
+    [[- end]]
+  [[- if .Security]]
+  volumes:
+  - name: tmp
+    emptyDir: {}
+  [[- end]]
+[[- end]]`
+
+const chartServiceTemplate = `{{- if .Values.service.enabled -}}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  type: {{ .Values.service.type }}
+  selector:
+    {{- include "[[.AppName]].selectorLabels" . | nindent 4 }}
+  ports:
+  - port: {{ .Values.service.port }}
+    targetPort: http
+{{- end }}
+`
+
+const chartIngressTemplate = `{{- if .Values.ingress.enabled -}}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+  {{- with .Values.ingress.annotations }}
+  annotations:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+spec:
+  {{- with .Values.ingress.className }}
+  ingressClassName: {{ . }}
+  {{- end }}
+  {{- with .Values.ingress.tls }}
+  tls:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+  rules:
+  {{- range .Values.ingress.hosts }}
+  - host: {{ .host | quote }}
+    http:
+      paths:
+      {{- range .paths }}
+      - path: {{ .path }}
+        pathType: {{ .pathType }}
+        backend:
+          service:
+            name: {{ include "[[.AppName]].fullname" $ }}
+            port:
+              number: {{ $.Values.service.port }}
+      {{- end }}
+  {{- end }}
+{{- end }}
+`
+
+const chartHTTPRouteTemplate = `{{- if .Values.httpRoute.enabled -}}
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  parentRefs:
+    {{- toYaml .Values.httpRoute.parentRefs | nindent 4 }}
+  {{- with .Values.httpRoute.hostnames }}
+  hostnames:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+  rules:
+    - matches:
+        - path:
+            type: PathPrefix
+            value: {{ .Values.httpRoute.path }}
+      backendRefs:
+        - name: {{ include "[[.AppName]].fullname" . }}
+          port: {{ .Values.service.port }}
+{{- end }}
+`
+
+const chartServiceAccountTemplate = `{{- if .Values.serviceAccount.create -}}
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ include "[[.AppName]].serviceAccountName" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+automountServiceAccountToken: {{ .Values.serviceAccount.automount }}
+{{- end }}
+`
+
+const chartNetworkPolicyTemplate = `{{- if .Values.networkPolicy.enabled -}}
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  podSelector:
+    matchLabels:
+      {{- include "[[.AppName]].selectorLabels" . | nindent 6 }}
+  policyTypes:
+  - Ingress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: {{ .Values.networkPolicy.ingressNamespace }}
+    ports:
+    - port: http
+{{- end }}
+`
+
+const chartHeadlessServiceTemplate = `# Gives the pods of the StatefulSet stable DNS names
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}-headless
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  clusterIP: None
+  selector:
+    {{- include "[[.AppName]].selectorLabels" . | nindent 4 }}
+  ports:
+  - name: http
+    port: {{ .Values.containerPort }}
+    targetPort: http
+`
+
+const chartHPATemplate = `{{- if .Values.autoscaling.enabled -}}
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: [[.Kind]]
+    name: {{ include "[[.AppName]].fullname" . }}
+  minReplicas: {{ .Values.autoscaling.minReplicas }}
+  maxReplicas: {{ .Values.autoscaling.maxReplicas }}
+  metrics:
+  {{- if .Values.autoscaling.targetCPUUtilizationPercentage }}
+  - type: Resource
+    resource:
+      name: cpu
+      target:
+        type: Utilization
+        averageUtilization: {{ .Values.autoscaling.targetCPUUtilizationPercentage }}
+  {{- end }}
+  {{- if .Values.autoscaling.targetMemoryUtilizationPercentage }}
+  - type: Resource
+    resource:
+      name: memory
+      target:
+        type: Utilization
+        averageUtilization: {{ .Values.autoscaling.targetMemoryUtilizationPercentage }}
+  {{- end }}
+{{- end }}
+`
+
+const chartPDBTemplate = `{{- if .Values.podDisruptionBudget.enabled -}}
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: {{ include "[[.AppName]].fullname" . }}
+  labels:
+    {{- include "[[.AppName]].labels" . | nindent 4 }}
+spec:
+  {{- with .Values.podDisruptionBudget.minAvailable }}
+  minAvailable: {{ if hasSuffix "%" (toString .) }}{{ . }}{{ else }}{{ int . }}{{ end }}
+  {{- else }}
+  maxUnavailable: {{ if hasSuffix "%" (toString .Values.podDisruptionBudget.maxUnavailable) }}{{ .Values.podDisruptionBudget.maxUnavailable }}{{ else }}{{ int .Values.podDisruptionBudget.maxUnavailable }}{{ end }}
+  {{- end }}
+  selector:
+    matchLabels:
+      {{- include "[[.AppName]].selectorLabels" . | nindent 6 }}
+{{- end }}
+`
+
+const chartConfigMapTemplate = `{{- with .Values.config -}}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "[[.AppName]].fullname" $ }}-config
+  labels:
+    {{- include "[[.AppName]].labels" $ | nindent 4 }}
+data:
+  {{- range $key, $value := . }}
+  {{ $key }}: {{ $value | quote }}
+  {{- end }}
+{{- end }}
+`
+
+const chartSecretTemplate = `{{- with .Values.secrets -}}
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ include "[[.AppName]].fullname" $ }}-secret
+  labels:
+    {{- include "[[.AppName]].labels" $ | nindent 4 }}
+type: Opaque
+stringData:
+  {{- range $key, $value := . }}
+  {{ $key }}: {{ $value | quote }}
+  {{- end }}
+{{- end }}
+`
+
+const notesTemplate = `[[if eq .Kind "Job" -]]
+Follow the job with:
+  kubectl --namespace {{ .Release.Namespace }} logs job/{{ include "[[.AppName]].fullname" . }} --follow
+[[- else if eq .Kind "CronJob"]]
+The job runs on the schedule {{ .Values.schedule | quote }}, trigger a run with:
+  kubectl --namespace {{ .Release.Namespace }} create job --from=cronjob/{{ include "[[.AppName]].fullname" . }} {{ include "[[.AppName]].fullname" . }}-manual
+[[- else -]]
+{{- if .Values.ingress.enabled }}
+The application is served at:
+{{- range .Values.ingress.hosts }}
+  http{{ if $.Values.ingress.tls }}s{{ end }}://{{ .host }}
+{{- end }}
+{{- else if .Values.httpRoute.enabled }}
+The application is served through the Gateway at:
+{{- range .Values.httpRoute.hostnames }}
+  http://{{ . }}
+{{- end }}
+{{- else if .Values.service.enabled }}
+Reach the application with:
+  kubectl --namespace {{ .Release.Namespace }} port-forward svc/{{ include "[[.AppName]].fullname" . }} 8080:{{ .Values.service.port }}
+  echo "Visit http://127.0.0.1:8080"
+{{- else }}
+Reach the application with:
+  kubectl --namespace {{ .Release.Namespace }} port-forward [[lower .Kind]]/{{ include "[[.AppName]].fullname" . }} 8080:{{ .Values.containerPort }}
+  echo "Visit http://127.0.0.1:8080"
+{{- end }}
+[[- end]]
 `
@@ -0,0 +1,165 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Image is the container image of the workload
+type Image struct {
+	// Registry is the registry host with an optional path, like ghcr.io/acme
+	Registry   string
+	Repository string
+	Tag        string
+	// Digest pins the image, like sha256:<hex>, and takes precedence over the tag
+	Digest string
+}
+
+var (
+	registryPattern   = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+	repositoryPattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+	tagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	digestPattern     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Name returns the image without its tag or digest
+func (i Image) Name() string {
+	if i.Registry == "" {
+		return i.Repository
+	}
+	return strings.TrimSuffix(i.Registry, "/") + "/" + i.Repository
+}
+
+// Reference returns the image as written in the manifests, pinned by digest when
+// one is set
+func (i Image) Reference() string {
+	if i.Digest != "" {
+		return i.Name() + "@" + i.Digest
+	}
+	return i.Name() + ":" + i.Tag
+}
+
+// Validate checks the image parts against the reference grammar of container registries
+func (i Image) Validate() error {
+	if i.Registry != "" && !registryPattern.MatchString(strings.TrimSuffix(i.Registry, "/")) {
+		return fmt.Errorf("invalid image registry %q, use a host like ghcr.io/acme", i.Registry)
+	}
+	if !repositoryPattern.MatchString(i.Repository) {
+		return fmt.Errorf("invalid image repository %q, use lowercase path components like acme/api", i.Repository)
+	}
+	if i.Digest != "" {
+		if !digestPattern.MatchString(i.Digest) {
+			return fmt.Errorf("invalid image digest %q, use sha256:<64 hex characters>", i.Digest)
+		}
+		return nil
+	}
+	if !tagPattern.MatchString(i.Tag) {
+		return fmt.Errorf("invalid image tag %q", i.Tag)
+	}
+	return nil
+}
+
+// PullPolicies are the supported image pull policies
+var PullPolicies = []string{"Always", "IfNotPresent", "Never"}
+
+// ParsePullPolicy validates an image pull policy, ignoring case
+func ParsePullPolicy(name string) (string, error) {
+	for _, policy := range PullPolicies {
+		if strings.EqualFold(name, policy) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported image pull policy %q, use %s", name, strings.Join(PullPolicies, ", "))
+}
+
+// Resources are the CPU and memory requests and limits of a container
+type Resources struct {
+	CPURequest    string
+	MemoryRequest string
+	CPULimit      string
+	MemoryLimit   string
+}
+
+// Sizes are the named resource presets
+var Sizes = map[string]Resources{
+	"small":  {CPURequest: "50m", MemoryRequest: "64Mi", CPULimit: "250m", MemoryLimit: "128Mi"},
+	"medium": {CPURequest: "100m", MemoryRequest: "128Mi", CPULimit: "500m", MemoryLimit: "256Mi"},
+	"large":  {CPURequest: "250m", MemoryRequest: "256Mi", CPULimit: "1", MemoryLimit: "512Mi"},
+}
+
+// DefaultSize is the preset used when no resources are given
+const DefaultSize = "medium"
+
+// ParseSize returns the resources of a named preset
+func ParseSize(name string) (Resources, error) {
+	resources, ok := Sizes[strings.ToLower(name)]
+	if !ok {
+		var names []string
+		for size := range Sizes {
+			names = append(names, size)
+		}
+		sort.Strings(names)
+		return Resources{}, fmt.Errorf("unsupported size %q, use %s", name, strings.Join(names, ", "))
+	}
+	return resources, nil
+}
+
+// Validate checks that every quantity is valid and that the requests do not exceed
+// the limits, which the API server would reject
+func (r Resources) Validate() error {
+	pairs := []struct {
+		name           string
+		request, limit string
+	}{
+		{"cpu", r.CPURequest, r.CPULimit},
+		{"memory", r.MemoryRequest, r.MemoryLimit},
+	}
+	for _, pair := range pairs {
+		request, err := ParseQuantity(pair.request)
+		if err != nil {
+			return fmt.Errorf("invalid %s request: %v", pair.name, err)
+		}
+		limit, err := ParseQuantity(pair.limit)
+		if err != nil {
+			return fmt.Errorf("invalid %s limit: %v", pair.name, err)
+		}
+		if request > limit {
+			return fmt.Errorf("%s request %s exceeds the limit %s", pair.name, pair.request, pair.limit)
+		}
+	}
+	return nil
+}
+
+// quantityPattern matches the Kubernetes quantity syntax: a decimal number followed
+// by a binary suffix (Ki, Mi...), a decimal suffix (m, k, M...) or an exponent
+var quantityPattern = regexp.MustCompile(`^(\+?[0-9]+(\.[0-9]*)?|\+?\.[0-9]+)(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E|[eE][+-]?[0-9]+)?$`)
+
+// quantitySuffixes are the multipliers of the quantity suffixes
+var quantitySuffixes = map[string]float64{
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40, "Pi": 1 << 50, "Ei": 1 << 60,
+	"n": 1e-9, "u": 1e-6, "m": 1e-3, "": 1, "k": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+}
+
+// ParseQuantity validates a non-negative Kubernetes quantity like 500m or 256Mi and
+// returns its value, precise enough to compare quantities
+func ParseQuantity(quantity string) (float64, error) {
+	match := quantityPattern.FindStringSubmatch(quantity)
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a valid quantity, use values like 500m, 1, 256Mi or 1Gi", quantity)
+	}
+
+	number, suffix := match[1], match[3]
+	multiplier, ok := quantitySuffixes[suffix]
+	if !ok {
+		// Exponents like 1e3 are parsed as part of the number
+		number, multiplier = number+suffix, 1
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid quantity: %v", quantity, err)
+	}
+	return value * multiplier, nil
+}
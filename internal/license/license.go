@@ -0,0 +1,301 @@
+package license
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// texts holds the license texts, rendered with the year and the copyright holder
+//
+//go:embed texts/*.txt
+var texts embed.FS
+
+// License is a license a project can be published under
+type License struct {
+	// ID is the SPDX identifier, or "proprietary"
+	ID   string
+	Name string
+	// Expression is the value of the license field in package.json and pyproject.toml
+	Expression string
+	// BadgeColor is the shields.io color of the README badge
+	BadgeColor string
+}
+
+// Proprietary is the ID of the all rights reserved license
+const Proprietary = "proprietary"
+
+// licenses holds every supported license keyed by ID
+var licenses = map[string]License{
+	"MIT":          {ID: "MIT", Name: "MIT License", Expression: "MIT", BadgeColor: "yellow"},
+	"Apache-2.0":   {ID: "Apache-2.0", Name: "Apache License 2.0", Expression: "Apache-2.0", BadgeColor: "blue"},
+	"BSD-3-Clause": {ID: "BSD-3-Clause", Name: "BSD 3-Clause License", Expression: "BSD-3-Clause", BadgeColor: "orange"},
+	"MPL-2.0":      {ID: "MPL-2.0", Name: "Mozilla Public License 2.0", Expression: "MPL-2.0", BadgeColor: "brightgreen"},
+	"GPL-3.0":      {ID: "GPL-3.0", Name: "GNU General Public License v3.0", Expression: "GPL-3.0-only", BadgeColor: "blue"},
+	// npm uses UNLICENSED for packages that are not meant to be used by others
+	Proprietary: {ID: Proprietary, Name: "Proprietary", Expression: "UNLICENSED", BadgeColor: "red"},
+}
+
+// order is the order licenses are listed in
+var order = []string{"MIT", "Apache-2.0", "BSD-3-Clause", "MPL-2.0", "GPL-3.0", Proprietary}
+
+// IDs returns the IDs of every supported license
+func IDs() []string {
+	return append([]string{}, order...)
+}
+
+// Parse returns the license with the given ID, ignoring case
+func Parse(id string) (License, error) {
+	for _, known := range order {
+		if strings.EqualFold(id, known) {
+			return licenses[known], nil
+		}
+	}
+	return License{}, fmt.Errorf("unsupported license %q, available licenses: %s", id, strings.Join(order, ", "))
+}
+
+// Render returns the full license text for the year and copyright holder
+func (l License) Render(year int, holder string) (string, error) {
+	text, err := texts.ReadFile("texts/" + l.ID + ".txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s license text: %v", l.ID, err)
+	}
+
+	tmpl, err := template.New(l.ID).Parse(string(text))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s license text: %v", l.ID, err)
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Year   int
+		Holder string
+	}{year, holder}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s license text: %v", l.ID, err)
+	}
+	return buf.String(), nil
+}
+
+// Badge returns the Markdown license badge linking to the LICENSE file
+func (l License) Badge() string {
+	// shields.io escapes dashes in badge text by doubling them
+	message := strings.ReplaceAll(l.ID, "-", "--")
+	if l.ID == Proprietary {
+		message = "Proprietary"
+	}
+	return fmt.Sprintf("[![License: %s](https://img.shields.io/badge/License-%s-%s.svg)](LICENSE)", l.Name, message, l.BadgeColor)
+}
+
+// DefaultHolder returns the copyright holder used when none is given: the git
+// user.name, or an empty string when it is not configured
+func DefaultHolder(dir string) string {
+	return utils.GitConfig(dir, "user.name")
+}
+
+// Writer writes a LICENSE file and references the license from the project files
+type Writer struct {
+	Dir     string
+	License License
+	Holder  string
+	Year    int
+	// Force overwrites an existing LICENSE file
+	Force bool
+	// Updated lists the project files that now reference the license
+	Updated []string
+}
+
+// NewWriter creates a new Writer for the project at dir, dated the current year
+func NewWriter(dir string, license License, holder string, force bool) *Writer {
+	return &Writer{
+		Dir:     dir,
+		License: license,
+		Holder:  holder,
+		Year:    time.Now().Year(),
+		Force:   force,
+	}
+}
+
+// Write renders the LICENSE file, sets the license field of package.json (also in the
+// web/ directory of full-stack projects) and pyproject.toml, and adds a badge below the
+// title of README.md. Project files that do not exist are skipped.
+//
+// Returns:
+//   - error: An error if LICENSE already exists without Force, or a file cannot be updated
+func (w *Writer) Write() error {
+	if w.Holder == "" {
+		return fmt.Errorf("no copyright holder given and git user.name is not configured")
+	}
+
+	target := filepath.Join(w.Dir, "LICENSE")
+	if !w.Force {
+		if _, err := os.Stat(target); err == nil {
+			return fmt.Errorf("LICENSE already exists, use --force to overwrite it")
+		}
+	}
+
+	text, err := w.License.Render(w.Year, w.Holder)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(target, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to create LICENSE: %v", err)
+	}
+
+	for _, name := range []string{"package.json", filepath.Join("web", "package.json")} {
+		path := filepath.Join(w.Dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := utils.SetJSONField(path, "license", w.License.Expression); err != nil {
+			return err
+		}
+		w.Updated = append(w.Updated, name)
+	}
+
+	pyproject := filepath.Join(w.Dir, "pyproject.toml")
+	if _, err := os.Stat(pyproject); err == nil {
+		if err := setPyprojectLicense(pyproject, w.License); err != nil {
+			return err
+		}
+		w.Updated = append(w.Updated, "pyproject.toml")
+	}
+
+	readme := filepath.Join(w.Dir, "README.md")
+	if _, err := os.Stat(readme); err == nil {
+		added, err := addBadge(readme, w.License.Badge())
+		if err != nil {
+			return err
+		}
+		if added {
+			w.Updated = append(w.Updated, "README.md")
+		}
+	}
+
+	return nil
+}
+
+// setPyprojectLicense sets the license of the [project] table of pyproject.toml,
+// replacing an existing license key or adding one after the version or name key
+func setPyprojectLicense(path string, license License) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	expression := license.Expression
+	if license.ID == Proprietary {
+		// PEP 639 expressions have no UNLICENSED, custom licenses use a LicenseRef
+		expression = "LicenseRef-Proprietary"
+	}
+	entry := fmt.Sprintf("license = %q", expression)
+
+	lines := strings.Split(string(content), "\n")
+	inProject := false
+	anchor := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			if inProject {
+				break
+			}
+			inProject = trimmed == "[project]"
+			if inProject {
+				anchor = i
+			}
+			continue
+		}
+		if !inProject {
+			continue
+		}
+
+		key := strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0])
+		switch key {
+		case "license":
+			lines[i] = entry
+			return utils.WriteLines(path, lines)
+		case "name", "version":
+			anchor = i
+		}
+	}
+	if anchor == -1 {
+		return fmt.Errorf("no [project] table found in %s", path)
+	}
+
+	result := append([]string{}, lines[:anchor+1]...)
+	result = append(result, entry)
+	result = append(result, lines[anchor+1:]...)
+	return utils.WriteLines(path, result)
+}
+
+// addBadge inserts the badge below the first heading of the README, replacing an
+// earlier license badge. It reports false when the README already has the badge.
+func addBadge(path string, badge string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if strings.Contains(string(content), badge) {
+		return false, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "[![License:") {
+			lines[i] = badge
+			return true, utils.WriteLines(path, lines)
+		}
+	}
+
+	position := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "# ") {
+			position = i + 1
+			break
+		}
+	}
+
+	inserted := []string{badge}
+	if position > 0 {
+		inserted = append([]string{""}, inserted...)
+	}
+	if position >= len(lines) || lines[position] != "" {
+		inserted = append(inserted, "")
+	}
+
+	result := append([]string{}, lines[:position]...)
+	result = append(result, inserted...)
+	result = append(result, lines[position:]...)
+	return true, utils.WriteLines(path, result)
+}
+
+// Prompt asks the user to pick a license
+func Prompt() License {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	white := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	fmt.Printf("\n%s Select a license:\n\n", white("📋"))
+	for i, id := range order {
+		fmt.Printf("%s %s\n", cyan(fmt.Sprintf("%d.", i+1)), id)
+		fmt.Printf("   %s\n", yellow(licenses[id].Name))
+	}
+	fmt.Printf("\n%s Enter your choice (1-%d): ", white("→"), len(order))
+
+	for {
+		var choice int
+		fmt.Scanln(&choice)
+		if choice >= 1 && choice <= len(order) {
+			return licenses[order[choice-1]]
+		}
+		fmt.Printf("%s Please enter a number between 1 and %d: ", yellow("!"), len(order))
+	}
+}
@@ -0,0 +1,101 @@
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender_AllLicenses(t *testing.T) {
+	for _, id := range IDs() {
+		l, err := Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		text, err := l.Render(2026, "Jane Doe")
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", id, err)
+		}
+		if len(text) < 200 {
+			t.Errorf("%s: expected the full license text, got %d bytes", id, len(text))
+		}
+	}
+
+	mit, _ := Parse("mit")
+	text, _ := mit.Render(2026, "Jane Doe")
+	if !strings.Contains(text, "Copyright (c) 2026 Jane Doe") {
+		t.Errorf("expected the year and holder in the MIT text, got:\n%s", text[:200])
+	}
+}
+
+func TestParse_Unknown(t *testing.T) {
+	if _, err := Parse("WTFPL"); err == nil {
+		t.Error("expected an error for an unsupported license")
+	}
+}
+
+func TestBadge(t *testing.T) {
+	apache, _ := Parse("Apache-2.0")
+	want := "[![License: Apache License 2.0](https://img.shields.io/badge/License-Apache--2.0-blue.svg)](LICENSE)"
+	if badge := apache.Badge(); badge != want {
+		t.Errorf("expected %s, got %s", want, badge)
+	}
+}
+
+func TestWrite_UpdatesProjectFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":   "{\n  \"name\": \"shop\",\n  \"version\": \"1.0.0\",\n  \"license\": \"ISC\",\n  \"scripts\": {}\n}\n",
+		"pyproject.toml": "[project]\nname = \"shop\"\nversion = \"0.1.0\"\n\n[tool.ruff]\nline-length = 100\n",
+		"README.md":      "# shop\n\nA shop.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, _ := Parse("MIT")
+	writer := NewWriter(dir, l, "Jane Doe", false)
+	if err := writer.Write(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	if !strings.HasPrefix(read("LICENSE"), "MIT License") {
+		t.Error("expected LICENSE to hold the MIT text")
+	}
+	if pkg := read("package.json"); pkg != strings.Replace(files["package.json"], "ISC", "MIT", 1) {
+		t.Errorf("expected only the license field to change, got:\n%s", pkg)
+	}
+	if !strings.Contains(read("pyproject.toml"), "version = \"0.1.0\"\nlicense = \"MIT\"\n\n[tool.ruff]") {
+		t.Errorf("expected the license in the [project] table, got:\n%s", read("pyproject.toml"))
+	}
+	if readme := read("README.md"); readme != "# shop\n\n"+l.Badge()+"\n\nA shop.\n" {
+		t.Errorf("expected the badge below the title, got:\n%s", readme)
+	}
+
+	if err := NewWriter(dir, l, "Jane Doe", false).Write(); err == nil {
+		t.Error("expected an error when LICENSE already exists")
+	}
+
+	// Switching license replaces the badge instead of adding a second one
+	gpl, _ := Parse("GPL-3.0")
+	if err := NewWriter(dir, gpl, "Jane Doe", true).Write(); err != nil {
+		t.Fatal(err)
+	}
+	if readme := read("README.md"); strings.Count(readme, "[![License:") != 1 || !strings.Contains(readme, gpl.Badge()) {
+		t.Errorf("expected a single GPL badge, got:\n%s", readme)
+	}
+	if !strings.Contains(read("package.json"), `"license": "GPL-3.0-only"`) {
+		t.Errorf("expected the GPL expression in package.json, got:\n%s", read("package.json"))
+	}
+}
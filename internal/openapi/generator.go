@@ -0,0 +1,420 @@
+package openapi
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// Generator scaffolds handlers, models and routes from an OpenAPI document
+// into an existing project.
+type Generator struct {
+	Document *Document
+	Project  *projects.ProjectInfo
+	// Warnings collects manual steps that could not be applied automatically.
+	Warnings []string
+}
+
+// NewGenerator creates a new Generator
+func NewGenerator(doc *Document, project *projects.ProjectInfo) *Generator {
+	return &Generator{
+		Document: doc,
+		Project:  project,
+	}
+}
+
+// templateData is the data passed to the code templates.
+type templateData struct {
+	Title     string
+	Version   string
+	Module    string
+	Models    []model
+	Endpoints []endpoint
+	UsesTime  bool
+	UsesModel bool
+}
+
+// model is a component schema converted to the target language.
+type model struct {
+	Name   string
+	Alias  string
+	Fields []field
+}
+
+// field is a single property of a model.
+type field struct {
+	Name     string
+	JSONName string
+	Type     string
+	Required bool
+}
+
+// endpoint is an operation converted to the target language.
+type endpoint struct {
+	Method      string
+	Path        string
+	Route       string
+	Handler     string
+	Summary     string
+	PathParams  []param
+	QueryParams []param
+	BodyType    string
+	Variables   []string
+}
+
+// param is a path or query parameter and the local variable holding its value.
+type param struct {
+	Name string
+	Var  string
+}
+
+// Generate writes the scaffolded files into the project directory.
+// Echo web projects receive models, handlers and routes under internal/,
+// Express and Fastify projects receive models and a router under src/.
+func (g *Generator) Generate() error {
+	switch {
+	case g.Project.Type == projects.GoLang && g.Project.GoType == projects.WebGo:
+		return g.generateGo()
+	case g.Project.Type == projects.NodeJS && (g.Project.NodeType == projects.Express || g.Project.NodeType == projects.Fastify):
+		return g.generateNode()
+	default:
+		return fmt.Errorf("OpenAPI scaffolding supports Go web (Echo), Express and Fastify projects, found %s", g.describeProject())
+	}
+}
+
+// describeProject returns a human readable project kind for error messages.
+func (g *Generator) describeProject() string {
+	if g.Project.Type == projects.GoLang {
+		return fmt.Sprintf("Go %s project", g.Project.GoType)
+	}
+	return fmt.Sprintf("%s project", g.Project.NodeType)
+}
+
+// generateGo writes the Echo models, handlers and routes and registers the routes.
+func (g *Generator) generateGo() error {
+	data := g.buildData(goType)
+	data.Module = g.Project.Name
+
+	files := []struct {
+		path     string
+		template string
+		skip     bool
+	}{
+		{"internal/models/openapi.go", goModelsTemplate, len(data.Models) == 0},
+		{"internal/handlers/openapi.go", goHandlersTemplate, false},
+		{"internal/routes/openapi.go", goRoutesTemplate, false},
+	}
+
+	for _, file := range files {
+		if file.skip {
+			continue
+		}
+		if err := utils.RenderFile(g.Project.Dir, file.path, file.template, templateFuncs, data); err != nil {
+			return err
+		}
+	}
+
+	return g.registerGoRoutes()
+}
+
+// registerGoRoutes calls the generated route registration from routes.Register.
+func (g *Generator) registerGoRoutes() error {
+	manual, err := projects.RegisterGoRoute(g.Project.Dir, "registerOpenAPIRoutes(e)")
+	if err != nil {
+		return err
+	}
+	if manual != "" {
+		g.Warnings = append(g.Warnings, manual)
+	}
+	return nil
+}
+
+// generateNode writes the TypeScript models and router and registers the router.
+func (g *Generator) generateNode() error {
+	data := g.buildData(tsType)
+
+	if len(data.Models) > 0 {
+		if err := utils.RenderFile(g.Project.Dir, "src/models/openapi.ts", tsModelsTemplate, templateFuncs, data); err != nil {
+			return err
+		}
+	}
+
+	routerTemplate, importLine, registerLine := expressRouterTemplate,
+		"import { openapiRouter } from './routes/openapi';", "app.use(openapiRouter);"
+	if g.Project.NodeType == projects.Fastify {
+		routerTemplate, importLine, registerLine = fastifyRouterTemplate,
+			"import { openapiRoutes } from './routes/openapi';", "app.register(openapiRoutes);"
+	}
+
+	if err := utils.RenderFile(g.Project.Dir, "src/routes/openapi.ts", routerTemplate, templateFuncs, data); err != nil {
+		return err
+	}
+
+	return g.registerNodeRouter(importLine, registerLine)
+}
+
+// registerNodeRouter imports the generated router in src/index.ts and mounts it
+// before the server starts listening.
+func (g *Generator) registerNodeRouter(importLine, registerLine string) error {
+	manual, err := projects.RegisterNodeRoute(g.Project.Dir, importLine, registerLine)
+	if err != nil {
+		return err
+	}
+	if manual != "" {
+		g.Warnings = append(g.Warnings, manual)
+	}
+	return nil
+}
+
+// templateFuncs are the functions available to the OpenAPI templates.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+}
+
+// typeMapper converts a schema into a type name of the target language.
+// The qualified flag asks for component references to be package qualified.
+type typeMapper func(schema *Schema, qualified bool) string
+
+// buildData converts the document into template data using the given type mapper.
+func (g *Generator) buildData(mapType typeMapper) templateData {
+	data := templateData{
+		Title:   g.Document.Info.Title,
+		Version: g.Document.Info.Version,
+	}
+
+	for _, name := range g.Document.SchemaNames() {
+		schema := g.Document.Components.Schemas[name]
+		m := model{Name: utils.PascalCase(name)}
+
+		if len(schema.Properties) == 0 || (schema.Type != "" && schema.Type != "object") {
+			m.Alias = mapType(schema, false)
+		} else {
+			for _, prop := range sortedKeys(schema.Properties) {
+				m.Fields = append(m.Fields, field{
+					Name:     utils.GoFieldName(prop),
+					JSONName: prop,
+					Type:     mapType(schema.Properties[prop], false),
+					Required: schema.IsRequired(prop),
+				})
+			}
+		}
+
+		data.Models = append(data.Models, m)
+	}
+
+	for _, m := range data.Models {
+		if strings.Contains(m.Alias, "time.Time") {
+			data.UsesTime = true
+		}
+		for _, f := range m.Fields {
+			if strings.Contains(f.Type, "time.Time") {
+				data.UsesTime = true
+			}
+		}
+	}
+
+	seen := map[string]int{}
+	for _, ep := range g.Document.Endpoints() {
+		e := endpoint{
+			Method:  ep.Method,
+			Path:    ep.Path,
+			Route:   echoRoute(ep.Path),
+			Handler: handlerName(ep),
+			Summary: firstLine(ep.Operation.Summary),
+		}
+
+		seen[e.Handler]++
+		if seen[e.Handler] > 1 {
+			e.Handler = fmt.Sprintf("%s%d", e.Handler, seen[e.Handler])
+		}
+
+		used := map[string]bool{}
+		for _, p := range ep.Parameters {
+			if p.Name == "" {
+				continue
+			}
+			switch p.In {
+			case "path":
+				name := goVarName(p.Name, used)
+				e.PathParams = append(e.PathParams, param{Name: p.Name, Var: name})
+				e.Variables = append(e.Variables, name)
+			case "query":
+				name := goVarName(p.Name, used)
+				e.QueryParams = append(e.QueryParams, param{Name: p.Name, Var: name})
+				e.Variables = append(e.Variables, name)
+			}
+		}
+
+		if body := ep.Operation.jsonBodySchema(); body != nil {
+			e.BodyType = mapType(body, true)
+			e.Variables = append(e.Variables, "body")
+			if body.RefName() != "" || (body.Items != nil && body.Items.RefName() != "") {
+				data.UsesModel = true
+			}
+		}
+
+		data.Endpoints = append(data.Endpoints, e)
+	}
+
+	return data
+}
+
+// goType maps a schema to a Go type.
+func goType(schema *Schema, qualified bool) string {
+	if schema == nil {
+		return "any"
+	}
+	if ref := schema.RefName(); ref != "" {
+		if qualified {
+			return "models." + utils.PascalCase(ref)
+		}
+		return utils.PascalCase(ref)
+	}
+
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "time.Time"
+		case "binary", "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+		return "int"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + goType(schema.Items, qualified)
+	case "object":
+		return "map[string]any"
+	}
+	return "any"
+}
+
+// tsType maps a schema to a TypeScript type.
+func tsType(schema *Schema, qualified bool) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if ref := schema.RefName(); ref != "" {
+		if qualified {
+			return "models." + utils.PascalCase(ref)
+		}
+		return utils.PascalCase(ref)
+	}
+
+	switch schema.Type {
+	case "string":
+		if len(schema.Enum) > 0 {
+			values := make([]string, len(schema.Enum))
+			for i, value := range schema.Enum {
+				values[i] = "'" + value + "'"
+			}
+			return strings.Join(values, " | ")
+		}
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := tsType(schema.Items, qualified)
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// handlerName derives an exported handler name from the operationId, or from
+// the method and path when the operation has no id.
+func handlerName(ep Endpoint) string {
+	if ep.Operation.OperationID != "" {
+		return utils.PascalCase(ep.Operation.OperationID)
+	}
+
+	name := utils.PascalCase(strings.ToLower(ep.Method))
+	for _, segment := range strings.Split(ep.Path, "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") {
+			name += "By" + utils.GoFieldName(strings.Trim(segment, "{}"))
+			continue
+		}
+		name += utils.PascalCase(segment)
+	}
+	return name
+}
+
+// echoRoute converts an OpenAPI path template such as /pets/{id} into the
+// /pets/:id form understood by Echo, Express and Fastify.
+func echoRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + strings.Trim(segment, "{}")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// goVarName converts a parameter name into a local Go variable name that does
+// not clash with keywords or the variables and packages used by the handler
+// template. Names already in use get a number, like id2 for a query parameter
+// named like a path parameter.
+func goVarName(s string, used map[string]bool) string {
+	name := utils.CamelCase(s)
+	if name == "" {
+		name = "param"
+	}
+
+	switch {
+	case token.IsKeyword(name):
+		name += "Param"
+	case name == "c" || name == "body" || name == "err" || name == "echo" || name == "http" || name == "models":
+		name += "Param"
+	}
+
+	unique := name
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s%d", name, n)
+	}
+	used[unique] = true
+	return unique
+}
+
+// firstLine returns the first line of a possibly multi-line summary.
+func firstLine(s string) string {
+	return strings.TrimSpace(strings.SplitN(s, "\n", 2)[0])
+}
+
+// sortedKeys returns the keys of a schema map in sorted order.
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/testutil"
+)
+
+const petstore = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      summary: Info for a specific pet
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+        tag:
+          type: [string, "null"]
+        bornAt:
+          type: string
+          format: date-time
+`
+
+// writeSpec writes the petstore document into a temporary file and loads it.
+func writeSpec(t *testing.T) *Document {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(path, []byte(petstore), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	doc, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return doc
+}
+
+func TestLoad(t *testing.T) {
+	doc := writeSpec(t)
+
+	endpoints := doc.Endpoints()
+	if len(endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(endpoints))
+	}
+	if endpoints[0].Method != "GET" || endpoints[1].Method != "POST" {
+		t.Fatalf("expected GET before POST, got %s and %s", endpoints[0].Method, endpoints[1].Method)
+	}
+	if len(endpoints[2].Parameters) != 1 || endpoints[2].Parameters[0].Name != "petId" {
+		t.Fatalf("expected path level parameter to be merged, got %+v", endpoints[2].Parameters)
+	}
+	if tag := doc.Components.Schemas["Pet"].Properties["tag"].Type; tag != "string" {
+		t.Fatalf("expected nullable type to resolve to string, got %q", tag)
+	}
+}
+
+func TestLoad_RejectsSwagger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swagger.yaml")
+	if err := os.WriteFile(path, []byte("swagger: \"2.0\"\npaths:\n  /: {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an error for a Swagger 2.0 document")
+	}
+}
+
+func TestGenerate_Go(t *testing.T) {
+	doc := writeSpec(t)
+	dir := t.TempDir()
+
+	routesDir := filepath.Join(dir, "internal", "routes")
+	if err := os.MkdirAll(routesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	routes := "package routes\n\nfunc Register(e *echo.Echo) {\n\te.GET(\"/\", hello)\n\t" + projects.RoutesMarker + "\n}\n"
+	if err := os.WriteFile(filepath.Join(routesDir, "routes.go"), []byte(routes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "petstore", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator(doc, project)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "models", "openapi.go"),
+		"ID     int64     `json:\"id\"`", "Tag    string    `json:\"tag,omitempty\"`", `import "time"`)
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "handlers", "openapi.go"),
+		"func ListPets(c echo.Context) error", `limit := c.QueryParam("limit")`,
+		"var body models.Pet", `petId := c.Param("petId")`, "func GetPetsByPetID(", `"petstore/internal/models"`)
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "routes", "openapi.go"),
+		`e.GET("/pets/:petId", handlers.GetPetsByPetID)`, `e.POST("/pets", handlers.CreatePet)`)
+	testutil.ExpectContains(t, filepath.Join(routesDir, "routes.go"), "\tregisterOpenAPIRoutes(e)\n\t"+projects.RoutesMarker)
+
+	if len(generator.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", generator.Warnings)
+	}
+}
+
+func TestGenerate_GoParameterNames(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Search
+  version: 1.0.0
+paths:
+  /items/{id}:
+    get:
+      operationId: getItem
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+        - {name: id, in: query, schema: {type: string}}
+        - {name: for, in: query, schema: {type: string}}
+        - {name: http, in: query, schema: {type: string}}
+`
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+	doc, err := Load(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Keywords, imported packages and names shared by a path and a query parameter
+	// must still give distinct valid variables
+	dir := t.TempDir()
+	project := &projects.ProjectInfo{Dir: dir, Name: "search", Type: projects.GoLang, GoType: projects.WebGo}
+	if err := NewGenerator(doc, project).Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "handlers", "openapi.go"),
+		`id := c.Param("id")`, `id2 := c.QueryParam("id")`,
+		`forParam := c.QueryParam("for")`, `httpParam := c.QueryParam("http")`)
+}
+
+func TestGenerate_Express(t *testing.T) {
+	doc := writeSpec(t)
+	dir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	index := "import express from 'express';\n\nconst app = express();\n\napp.listen(3000, () => {});\n"
+	if err := os.WriteFile(filepath.Join(dir, "src", "index.ts"), []byte(index), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "petstore", Type: projects.NodeJS, NodeType: projects.Express}
+	if err := NewGenerator(doc, project).Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "models", "openapi.ts"), "export interface Pet {", "'tag'?: string;")
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "routes", "openapi.ts"),
+		"openapiRouter.get('/pets/:petId'", "const body = req.body as models.Pet;")
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "index.ts"),
+		"import express from 'express';\nimport { openapiRouter } from './routes/openapi';",
+		"app.use(openapiRouter);\n\napp.listen(")
+}
+
+func TestGenerate_UnsupportedProject(t *testing.T) {
+	doc := writeSpec(t)
+	project := &projects.ProjectInfo{Dir: t.TempDir(), Type: projects.GoLang, GoType: projects.PlainGo}
+
+	if err := NewGenerator(doc, project).Generate(); err == nil {
+		t.Fatal("expected an error for a plain Go project")
+	}
+}
@@ -0,0 +1,245 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the subset of an OpenAPI 3 document needed to scaffold code.
+type Document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components Components          `yaml:"components"`
+}
+
+// Info holds the document metadata.
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// Components holds the reusable schemas of the document.
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Parameters []Parameter `yaml:"parameters"`
+	Get        *Operation  `yaml:"get"`
+	Put        *Operation  `yaml:"put"`
+	Post       *Operation  `yaml:"post"`
+	Delete     *Operation  `yaml:"delete"`
+	Patch      *Operation  `yaml:"patch"`
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Parameters  []Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody        `yaml:"requestBody"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter describes a path, query, header or cookie parameter.
+type Parameter struct {
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// RequestBody describes the payload accepted by an operation.
+type RequestBody struct {
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+// MediaType holds the schema for one content type.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Schema is a JSON schema as used by OpenAPI.
+type Schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       SchemaType         `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Properties map[string]*Schema `yaml:"properties"`
+	Required   []string           `yaml:"required"`
+	Items      *Schema            `yaml:"items"`
+	Enum       []string           `yaml:"enum"`
+}
+
+// SchemaType is the type keyword of a schema. OpenAPI 3.1 allows a list of
+// types such as [string, "null"]; the first non-null entry is kept.
+type SchemaType string
+
+// UnmarshalYAML accepts both the scalar and the list form of the type keyword.
+func (t *SchemaType) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*t = SchemaType(value.Value)
+		return nil
+	}
+
+	var types []string
+	if err := value.Decode(&types); err != nil {
+		return err
+	}
+	for _, candidate := range types {
+		if candidate != "null" {
+			*t = SchemaType(candidate)
+			break
+		}
+	}
+	return nil
+}
+
+// RefName returns the component name a $ref points to, e.g. "Pet" for
+// "#/components/schemas/Pet". It returns an empty string for inline schemas.
+func (s *Schema) RefName() string {
+	if s == nil || s.Ref == "" {
+		return ""
+	}
+	return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
+}
+
+// IsRequired reports whether the named property is listed as required.
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoint is a single operation together with its method and path.
+type Endpoint struct {
+	Method     string
+	Path       string
+	Operation  *Operation
+	Parameters []Parameter
+}
+
+// Load reads an OpenAPI 3 document from a YAML or JSON file.
+//
+// Parameters:
+//   - path: The path of the specification file
+//
+// Returns:
+//   - *Document: The parsed document
+//   - error: An error if the file cannot be read, parsed or is not OpenAPI 3
+func Load(path string) (*Document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %v", err)
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %v", err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: only OpenAPI 3 documents are supported", doc.OpenAPI)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI document %s defines no paths", path)
+	}
+
+	return &doc, nil
+}
+
+// Endpoints returns every operation of the document sorted by path and method.
+// Path level parameters are merged into the parameters of each operation.
+func (d *Document) Endpoints() []Endpoint {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var endpoints []Endpoint
+	for _, path := range paths {
+		item := d.Paths[path]
+		operations := []struct {
+			method    string
+			operation *Operation
+		}{
+			{"GET", item.Get},
+			{"POST", item.Post},
+			{"PUT", item.Put},
+			{"PATCH", item.Patch},
+			{"DELETE", item.Delete},
+		}
+
+		for _, op := range operations {
+			if op.operation == nil {
+				continue
+			}
+			endpoints = append(endpoints, Endpoint{
+				Method:     op.method,
+				Path:       path,
+				Operation:  op.operation,
+				Parameters: mergeParameters(item.Parameters, op.operation.Parameters),
+			})
+		}
+	}
+
+	return endpoints
+}
+
+// SchemaNames returns the names of the component schemas in sorted order.
+func (d *Document) SchemaNames() []string {
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mergeParameters combines path level and operation level parameters.
+// Operation parameters override path parameters with the same name and location.
+func mergeParameters(pathParams, opParams []Parameter) []Parameter {
+	merged := append([]Parameter{}, opParams...)
+	for _, param := range pathParams {
+		overridden := false
+		for _, op := range opParams {
+			if op.Name == param.Name && op.In == param.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return merged
+}
+
+// jsonBodySchema returns the JSON schema of the request body, if any.
+func (o *Operation) jsonBodySchema() *Schema {
+	if o.RequestBody == nil {
+		return nil
+	}
+	for contentType, media := range o.RequestBody.Content {
+		if strings.Contains(contentType, "json") {
+			return media.Schema
+		}
+	}
+	return nil
+}
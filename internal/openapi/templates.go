@@ -0,0 +1,143 @@
+package openapi
+
+const goModelsTemplate = `// Models generated from the OpenAPI document "{{.Title}}" ({{.Version}}).
+
+package models
+{{if .UsesTime}}
+import "time"
+{{end}}
+{{- range .Models}}
+// {{.Name}} is generated from the {{.Name}} schema.
+{{- if .Alias}}
+type {{.Name}} {{.Alias}}
+{{else}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSONName}}{{if not .Required}},omitempty{{end}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+{{- end}}
+`
+
+const goHandlersTemplate = `// Handlers generated from the OpenAPI document "{{.Title}}" ({{.Version}}).
+// Every handler responds with 501 Not Implemented until filled in.
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+{{- if .UsesModel}}
+
+	"{{.Module}}/internal/models"
+{{- end}}
+)
+{{range .Endpoints}}
+// {{.Handler}} handles {{.Method}} {{.Path}}.
+{{- if .Summary}}
+// {{.Summary}}
+{{- end}}
+func {{.Handler}}(c echo.Context) error {
+{{- range .PathParams}}
+	{{.Var}} := c.Param("{{.Name}}")
+{{- end}}
+{{- range .QueryParams}}
+	{{.Var}} := c.QueryParam("{{.Name}}")
+{{- end}}
+{{- if .BodyType}}
+{{- if or .PathParams .QueryParams}}
+{{end}}
+	var body {{.BodyType}}
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+{{- end}}
+
+	// TODO: implement {{.Handler}}
+{{- range .Variables}}
+	_ = {{.}}
+{{- end}}
+	return c.JSON(http.StatusNotImplemented, map[string]string{
+		"message": "not implemented",
+	})
+}
+{{end}}`
+
+const goRoutesTemplate = `package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"{{.Module}}/internal/handlers"
+)
+
+// registerOpenAPIRoutes registers the routes generated from the OpenAPI
+// document "{{.Title}}" ({{.Version}}).
+func registerOpenAPIRoutes(e *echo.Echo) {
+{{- range .Endpoints}}
+	e.{{.Method}}("{{.Route}}", handlers.{{.Handler}})
+{{- end}}
+}
+`
+
+const tsModelsTemplate = `// Models generated from the OpenAPI document "{{.Title}}" ({{.Version}}).
+{{range .Models}}
+{{- if .Alias}}
+export type {{.Name}} = {{.Alias}};
+{{else}}
+export interface {{.Name}} {
+{{- range .Fields}}
+  '{{.JSONName}}'{{if not .Required}}?{{end}}: {{.Type}};
+{{- end}}
+}
+{{end}}
+{{end}}`
+
+const expressRouterTemplate = `// Routes generated from the OpenAPI document "{{.Title}}" ({{.Version}}).
+// Every handler responds with 501 Not Implemented until filled in.
+import express, { Request, Response, Router } from 'express';
+{{- if .UsesModel}}
+import * as models from '../models/openapi';
+{{- end}}
+
+export const openapiRouter = Router();
+openapiRouter.use(express.json());
+{{range .Endpoints}}
+// {{.Method}} {{.Path}}{{if .Summary}} - {{.Summary}}{{end}}
+openapiRouter.{{lower .Method}}('{{.Route}}', (req: Request, res: Response) => {
+{{- if .BodyType}}
+  const body = req.body as {{.BodyType}};
+  void body;
+{{- end}}
+  res.status(501).json({ message: 'Not implemented' });
+});
+{{end}}`
+
+const fastifyRouterTemplate = `// Routes generated from the OpenAPI document "{{.Title}}" ({{.Version}}).
+// Every handler responds with 501 Not Implemented until filled in.
+import type { FastifyInstance } from 'fastify';
+{{- if .UsesModel}}
+import * as models from '../models/openapi';
+{{- end}}
+
+export async function openapiRoutes(app: FastifyInstance) {
+{{- range .Endpoints}}
+  // {{.Method}} {{.Path}}{{if .Summary}} - {{.Summary}}{{end}}
+  app.{{lower .Method}}<{
+{{- if .PathParams}}
+    Params: { {{range .PathParams}}'{{.Name}}': string; {{end}}};
+{{- end}}
+{{- if .QueryParams}}
+    Querystring: { {{range .QueryParams}}'{{.Name}}'?: string; {{end}}};
+{{- end}}
+{{- if .BodyType}}
+    Body: {{.BodyType}};
+{{- end}}
+  }>('{{.Route}}', async (_request, reply) => {
+    return reply.code(501).send({ message: 'Not implemented' });
+  });
+{{end}}
+}
+`
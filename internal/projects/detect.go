@@ -0,0 +1,210 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectInfo describes a project that already exists on disk.
+// It is used by commands that extend a project after it has been created.
+type ProjectInfo struct {
+	Dir      string
+	Name     string
+	Type     ProjectType
+	GoType   GoProjectType
+	NodeType NodeProjectType
+	// PackageManager is the package manager of Node.js projects, derived from the lockfile
+	PackageManager PackageManager
+
+	// dependencies holds the package.json dependencies of Node.js projects
+	dependencies map[string]bool
+	// scripts holds the package.json scripts of Node.js projects
+	scripts map[string]string
+}
+
+// HasDependency reports whether a Node.js project lists the package as a
+// dependency or dev dependency.
+func (i *ProjectInfo) HasDependency(name string) bool {
+	return i.dependencies[name]
+}
+
+// HasScript reports whether a Node.js project defines the package.json script.
+// The placeholder test script written by "npm init" does not count.
+func (i *ProjectInfo) HasScript(name string) bool {
+	script, ok := i.scripts[name]
+	return ok && !strings.Contains(script, "no test specified")
+}
+
+// packageJSON holds the parts of package.json needed for project detection.
+type packageJSON struct {
+	Name            string            `json:"name"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+	Scripts         map[string]string `json:"scripts"`
+}
+
+// DetectProject inspects the given directory and reports which kind of project it contains.
+// Go projects are recognised by their go.mod file and Node.js projects by their package.json.
+//
+// Parameters:
+//   - dir: The project root directory
+//
+// Returns:
+//   - *ProjectInfo: The detected project information
+//   - error: An error if the directory does not contain a supported project
+func DetectProject(dir string) (*ProjectInfo, error) {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		return detectGoProject(dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "package.json")); err == nil {
+		return detectNodeProject(dir)
+	}
+	return nil, fmt.Errorf("no go.mod or package.json found in %s", dir)
+}
+
+// IsFullStack reports whether dir holds a full-stack project, a Go API in api/ and
+// a frontend in web/.
+func IsFullStack(dir string) bool {
+	for _, file := range []string{filepath.Join("api", "go.mod"), filepath.Join("web", "package.json")} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectApps detects the applications of the repository at dir: the API and the
+// frontend of a full-stack project, or the project at dir itself.
+//
+// Parameters:
+//   - dir: The repository root directory
+//
+// Returns:
+//   - []*ProjectInfo: The detected applications, api/ before web/
+//   - error: An error if one of the directories does not contain a supported project
+func DetectApps(dir string) ([]*ProjectInfo, error) {
+	dirs := []string{dir}
+	if IsFullStack(dir) {
+		dirs = []string{filepath.Join(dir, "api"), filepath.Join(dir, "web")}
+	}
+
+	var apps []*ProjectInfo
+	for _, d := range dirs {
+		info, err := DetectProject(d)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, info)
+	}
+	return apps, nil
+}
+
+// HasProjectType reports whether one of the applications has the project type.
+func HasProjectType(apps []*ProjectInfo, projectType ProjectType) bool {
+	for _, app := range apps {
+		if app.Type == projectType {
+			return true
+		}
+	}
+	return false
+}
+
+// RelativePath returns the project directory relative to root with forward
+// slashes, "." for the project at root itself.
+func (i *ProjectInfo) RelativePath(root string) string {
+	rel, err := filepath.Rel(root, i.Dir)
+	if err != nil {
+		return "."
+	}
+	return filepath.ToSlash(rel)
+}
+
+// detectGoProject reads go.mod to find the module path and whether the project uses Echo.
+func detectGoProject(dir string) (*ProjectInfo, error) {
+	file, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %v", err)
+	}
+	defer file.Close()
+
+	info := &ProjectInfo{Dir: dir, Type: GoLang, GoType: PlainGo}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			info.Name = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+		if strings.Contains(line, "github.com/labstack/echo/v4") {
+			info.GoType = WebGo
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %v", err)
+	}
+	if info.Name == "" {
+		return nil, fmt.Errorf("go.mod in %s has no module directive", dir)
+	}
+
+	return info, nil
+}
+
+// detectNodeProject reads package.json and derives the Node.js project type from its dependencies.
+func detectNodeProject(dir string) (*ProjectInfo, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %v", err)
+	}
+
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %v", err)
+	}
+
+	info := &ProjectInfo{
+		Dir:            dir,
+		Name:           pkg.Name,
+		Type:           NodeJS,
+		NodeType:       TypeScriptBasic,
+		PackageManager: DetectPackageManager(dir),
+		dependencies:   map[string]bool{},
+		scripts:        pkg.Scripts,
+	}
+	if info.Name == "" {
+		info.Name = filepath.Base(dir)
+	}
+	for dep := range pkg.Dependencies {
+		info.dependencies[dep] = true
+	}
+	for dep := range pkg.DevDependencies {
+		info.dependencies[dep] = true
+	}
+	has := info.HasDependency
+
+	// Order matters: frameworks that build on top of others are checked first.
+	switch {
+	case has("@nestjs/core"):
+		info.NodeType = NestJS
+	case has("next"):
+		info.NodeType = NextJS
+	case has("@remix-run/node"), has("@remix-run/react"):
+		info.NodeType = Remix
+	case has("vite") && has("react"):
+		info.NodeType = ViteReact
+	case has("vite") && has("vue"):
+		info.NodeType = ViteVue
+	case has("vite") && has("svelte"):
+		info.NodeType = ViteSvelte
+	case has("vite") && has("solid-js"):
+		info.NodeType = ViteSolid
+	case has("fastify"):
+		info.NodeType = Fastify
+	case has("express"):
+		info.NodeType = Express
+	}
+
+	return info, nil
+}
@@ -0,0 +1,118 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectProject_Go(t *testing.T) {
+	dir := t.TempDir()
+	goMod := "module example.com/api\n\ngo 1.23\n\nrequire github.com/labstack/echo/v4 v4.13.3\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := DetectProject(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if info.Type != GoLang || info.GoType != WebGo || info.Name != "example.com/api" {
+		t.Fatalf("unexpected project info: %+v", info)
+	}
+}
+
+func TestDetectProject_Node(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      string
+		expected NodeProjectType
+	}{
+		{"nest", `{"name":"svc","dependencies":{"@nestjs/core":"^10.0.0","express":"^4.0.0"}}`, NestJS},
+		{"fastify", `{"name":"svc","dependencies":{"fastify":"^5.0.0"}}`, Fastify},
+		{"vite-react", `{"name":"web","dependencies":{"react":"^19.0.0"},"devDependencies":{"vite":"^6.0.0"}}`, ViteReact},
+		{"vite-vue", `{"name":"web","dependencies":{"vue":"^3.5.0"},"devDependencies":{"vite":"^6.0.0"}}`, ViteVue},
+		{"express", `{"name":"svc","dependencies":{"express":"^4.0.0"}}`, Express},
+		{"typescript", `{"name":"svc","devDependencies":{"typescript":"^5.0.0"}}`, TypeScriptBasic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(tt.pkg), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := DetectProject(dir)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if info.Type != NodeJS || info.NodeType != tt.expected {
+				t.Fatalf("expected %s, got %+v", tt.expected, info)
+			}
+		})
+	}
+}
+
+func TestDetectProject_PackageManager(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"package.json", "pnpm-lock.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info, err := DetectProject(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if info.PackageManager != PNPM {
+		t.Fatalf("expected pnpm, got %s", info.PackageManager)
+	}
+}
+
+func TestDetectProject_Unknown(t *testing.T) {
+	if _, err := DetectProject(t.TempDir()); err == nil {
+		t.Fatal("expected an error for an empty directory")
+	}
+}
+
+func TestDetectApps_FullStack(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		filepath.Join("api", "go.mod"):       "module example.com/shop/api\n",
+		filepath.Join("web", "package.json"): "{}",
+		filepath.Join("web", "bun.lockb"):    "",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	apps, err := DetectApps(dir)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(apps) != 2 || apps[0].RelativePath(dir) != "api" || apps[1].RelativePath(dir) != "web" {
+		t.Fatalf("expected the api and web apps, got %+v", apps)
+	}
+	if !HasProjectType(apps, GoLang) || !HasProjectType(apps, NodeJS) {
+		t.Errorf("expected a Go and a Node.js app, got %+v", apps)
+	}
+	if pm := apps[1].PackageManager.OrDefault(); pm != Bun {
+		t.Errorf("expected bun from bun.lockb, got %s", pm)
+	}
+
+	// The api directory alone is a single project
+	apps, err = DetectApps(filepath.Join(dir, "api"))
+	if err != nil || len(apps) != 1 || apps[0].RelativePath(filepath.Join(dir, "api")) != "." {
+		t.Errorf("expected the api project itself, got %+v, %v", apps, err)
+	}
+	if pm := apps[0].PackageManager.OrDefault(); pm != NPM {
+		t.Errorf("expected npm by default, got %s", pm)
+	}
+}
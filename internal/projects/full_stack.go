@@ -0,0 +1,406 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/fatih/color"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// APIPort is the port the Go API of full-stack projects listens on.
+const APIPort = 8080
+
+// viteProxyContent configures the Vite dev server to forward /api requests to the Go API.
+const viteProxyContent = `  server: {
+    host: true,
+    proxy: {
+      '/api': {
+        target: process.env.API_URL ?? 'http://localhost:{{.APIPort}}',
+        changeOrigin: true,
+        rewrite: (path) => path.replace(/^\/api/, ''),
+      },
+    },
+  },`
+
+// nextProxyContent configures Next.js rewrites forwarding /api requests to the Go API.
+const nextProxyContent = `  async rewrites() {
+    return [
+      {
+        source: '/api/:path*',
+        destination: ` + "`${process.env.API_URL ?? 'http://localhost:{{.APIPort}}'}/:path*`" + `,
+      },
+    ];
+  },`
+
+// fullStackReadmeContent is the root README.md of full-stack projects.
+const fullStackReadmeContent = `# {{.Name}}
+
+## Description
+A full-stack application with a Go Echo API and a {{.FrontendName}} frontend.
+
+## Project Structure
+- api/: Go Echo API listening on port {{.APIPort}}
+- web/: {{.FrontendName}} frontend served on port {{.WebPort}}
+- compose.yaml: Runs both applications with Docker Compose
+- Makefile: Common development commands
+
+The frontend dev server proxies every request under /api to the API, so the
+frontend can call ` + "`fetch('/api/...')`" + ` without any CORS configuration.
+
+## Getting Started
+1. Install dependencies:
+   ~~~
+   make install
+   ~~~
+
+2. Run the API and the frontend together:
+   ~~~
+   make dev
+   ~~~
+
+   Or run both in containers:
+   ~~~
+   docker compose up
+   ~~~
+
+3. Open http://localhost:{{.WebPort}}
+`
+
+// fullStackMakefileContent is the root Makefile of full-stack projects.
+const fullStackMakefileContent = `.DEFAULT_GOAL := help
+
+.PHONY: help install dev api web build up down
+
+help: ## Show this help
+	@awk 'BEGIN {FS = ":.*## "} /^[a-zA-Z0-9_-]+:.*## / {printf "  \033[36m%-12s\033[0m %s\n", $$1, $$2}' $(MAKEFILE_LIST)
+
+install: ## Install the API and frontend dependencies
+	cd api && go mod download
+	cd web && {{.Install}}
+
+dev: ## Run the API and the frontend together
+	$(MAKE) -j2 api web
+
+api: ## Run the API
+	cd api && go run ./cmd/main.go
+
+web: ## Run the frontend dev server
+	cd web && {{.RunDev}}
+
+build: ## Build the API binary and the frontend
+	cd api && go build -o bin/api ./cmd/main.go
+	cd web && {{.RunBuild}}
+
+up: ## Run both applications with Docker Compose
+	docker compose up
+
+down: ## Stop the Docker Compose stack
+	docker compose down
+`
+
+// fullStackComposeContent is the root compose.yaml of full-stack projects.
+const fullStackComposeContent = `services:
+  api:
+    image: golang:1-alpine
+    working_dir: /app
+    command: go run ./cmd/main.go
+    ports:
+      - "{{.APIPort}}:{{.APIPort}}"
+    volumes:
+      - ./api:/app
+      - go-mod-cache:/go/pkg/mod
+
+  web:
+    image: {{.NodeImage}}
+    working_dir: /app
+    command: sh -c "{{.ComposeCommand}}"
+    ports:
+      - "{{.WebPort}}:{{.WebPort}}"
+    environment:
+      API_URL: http://api:{{.APIPort}}
+    volumes:
+      - ./web:/app
+      - /app/node_modules
+    depends_on:
+      - api
+
+volumes:
+  go-mod-cache:
+`
+
+// FullStackProject represents a project made of a Go web API in api/ and
+// a Node.js frontend in web/.
+type FullStackProject struct {
+	Name string
+	Dir  string
+	// Frontend is the Node.js project type of the frontend, prompted for when empty
+	Frontend NodeProjectType
+	// PackageManager is the package manager of the frontend, prompted for when empty
+	PackageManager PackageManager
+}
+
+// fullStackData holds the values rendered into the root files of a full-stack project.
+type fullStackData struct {
+	Name           string
+	FrontendName   string
+	APIPort        int
+	WebPort        int
+	Install        string
+	RunDev         string
+	RunBuild       string
+	NodeImage      string
+	ComposeCommand string
+}
+
+// Create initializes the Go API and the frontend, points the frontend dev server
+// at the API and writes the root README, Makefile and compose file.
+// Returns an error if any of the projects or files cannot be created.
+func (p *FullStackProject) Create() error {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if p.Frontend == "" {
+		p.Frontend = promptFrontendType()
+	}
+
+	fmt.Printf("\n🧩 Creating new full-stack project: %s (go + %s)\n", cyan(p.Name), cyan(p.Frontend))
+
+	apiDir := filepath.Join(p.Dir, "api")
+	webDir := filepath.Join(p.Dir, "web")
+	for _, dir := range []string{apiDir, webDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("%s Failed to create %s: %v", red("✘"), dir, err)
+		}
+	}
+
+	api := &GoProject{Name: p.Name + "/api", Dir: apiDir, ProjectType: WebGo}
+	if err := api.Create(); err != nil {
+		return err
+	}
+
+	web := &NodeProject{Name: p.Name + "/web", Dir: webDir, ProjectType: p.Frontend, PackageManager: p.PackageManager}
+	if err := web.Create(); err != nil {
+		return err
+	}
+	p.PackageManager = web.PackageManager
+
+	if err := ChangeDirectory(p.Dir); err != nil {
+		return fmt.Errorf("%s Failed to access directory: %v", red("✘"), err)
+	}
+
+	steps := []ProjectSteps{
+		{
+			Name: "Configure API proxy",
+			Action: func() error {
+				return ConfigureFrontendProxy(webDir, p.Frontend, p.PackageManager)
+			},
+			Message: fmt.Sprintf("Frontend proxies /api to port %d", APIPort),
+		},
+		{
+			Name: "Create root files",
+			Action: func() error {
+				return p.writeRootFiles()
+			},
+			Message: "README.md, Makefile and compose.yaml created",
+		},
+	}
+
+	for _, step := range steps {
+		s := utils.CreateSpinner(step.Name + "...")
+		s.Start()
+		if err := step.Action(); err != nil {
+			s.Stop()
+			return fmt.Errorf("%s %s: %v", red("✘"), step.Name, err)
+		}
+		s.Stop()
+		fmt.Printf("%s %s\n", green("✓"), step.Message)
+	}
+
+	fmt.Printf("\n%s Full-stack project created successfully!\n\n", green("✨"))
+	fmt.Println("Next steps:")
+	fmt.Printf("  cd %s\n", cyan(p.Name))
+	fmt.Printf("  %s\n", cyan("make dev"))
+	fmt.Printf("  %s\n", cyan("docker compose up"))
+	fmt.Println()
+	return nil
+}
+
+// writeRootFiles renders the README, Makefile and compose file in the project root.
+func (p *FullStackProject) writeRootFiles() error {
+	data := p.templateData()
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"README.md", fullStackReadmeContent},
+		{"Makefile", fullStackMakefileContent},
+		{"compose.yaml", fullStackComposeContent},
+	}
+
+	for _, file := range files {
+		content, err := renderTemplate(file.name, file.content, data)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(p.Dir, file.name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", file.name, err)
+		}
+	}
+
+	return nil
+}
+
+// templateData derives the values of the root files from the frontend and package manager.
+func (p *FullStackProject) templateData() fullStackData {
+	pm := p.PackageManager.OrDefault()
+
+	data := fullStackData{
+		Name:         p.Name,
+		FrontendName: frontendName(p.Frontend),
+		APIPort:      APIPort,
+		WebPort:      FrontendPort(p.Frontend),
+		Install:      strings.Join(pm.Install().Args, " "),
+		RunDev:       pm.Run("dev"),
+		RunBuild:     pm.Run("build"),
+		NodeImage:    "node:22-alpine",
+	}
+
+	data.ComposeCommand = data.Install + " && " + data.RunDev
+	switch pm {
+	case PNPM, Yarn:
+		data.ComposeCommand = "corepack enable && " + data.ComposeCommand
+	case Bun:
+		data.NodeImage = "oven/bun:1-alpine"
+	}
+
+	return data
+}
+
+// ConfigureFrontendProxy makes the dev server of a Next.js or Vite frontend forward
+// requests under /api to the Go API. The target defaults to localhost and can be
+// overridden with the API_URL environment variable, as done in compose.yaml.
+//
+// Parameters:
+//   - dir: The frontend project directory
+//   - projectType: The Node.js project type of the frontend
+//   - pm: The package manager of the frontend
+//
+// Returns:
+//   - error: An error if the configuration file cannot be updated
+func ConfigureFrontendProxy(dir string, projectType NodeProjectType, pm PackageManager) error {
+	data := struct{ APIPort int }{APIPort}
+
+	switch {
+	case projectType == NextJS:
+		proxy, err := renderTemplate("next proxy", nextProxyContent, data)
+		if err != nil {
+			return err
+		}
+		return insertProxy(filepath.Join(dir, "next.config.ts"), "};", proxy)
+	case projectType.IsVite():
+		proxy, err := renderTemplate("vite proxy", viteProxyContent, data)
+		if err != nil {
+			return err
+		}
+		if err := insertProxy(filepath.Join(dir, "vite.config.ts"), "})", proxy); err != nil {
+			return err
+		}
+
+		// vite.config.ts reads process.env, which needs the Node.js type definitions
+		cmd := pm.Add(true, "@types/node")
+		cmd.Dir = dir
+		return cmd.Run()
+	default:
+		return fmt.Errorf("%s frontends are not supported in full-stack projects", projectType)
+	}
+}
+
+// insertProxy adds the proxy configuration before the closing line of the config object.
+func insertProxy(path string, closing string, proxy string) error {
+	found, err := utils.InsertBeforeLine(path, closing, proxy)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("could not find the end of the configuration in %s", filepath.Base(path))
+	}
+	return nil
+}
+
+// FrontendPort returns the port the dev server of a frontend listens on.
+func FrontendPort(projectType NodeProjectType) int {
+	if projectType.IsVite() {
+		return 5173
+	}
+	return 3000
+}
+
+// frontendName returns the menu name of a Node.js project type.
+func frontendName(projectType NodeProjectType) string {
+	for _, opt := range GetNodeProjectOptions() {
+		if opt.Type == projectType {
+			return opt.Name
+		}
+	}
+	return string(projectType)
+}
+
+// frontendOptions returns the Node.js project types usable as a full-stack frontend.
+func frontendOptions() []NodeProjectOption {
+	var options []NodeProjectOption
+	for _, opt := range GetNodeProjectOptions() {
+		if opt.Type == NextJS || opt.Type.IsVite() {
+			options = append(options, opt)
+		}
+	}
+	return options
+}
+
+// promptFrontendType prompts the user to select the frontend of a full-stack project
+func promptFrontendType() NodeProjectType {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	white := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	options := frontendOptions()
+
+	fmt.Printf("\n%s Select the frontend:\n\n", white("📋"))
+
+	for i, opt := range options {
+		fmt.Printf("%s %s\n", cyan(fmt.Sprintf("%d.", i+1)), opt.Name)
+		fmt.Printf("   %s\n", yellow(opt.Description))
+	}
+
+	fmt.Printf("\n%s Enter your choice (1-%d): ", white("→"), len(options))
+
+	var choice int
+	for {
+		fmt.Scanln(&choice)
+		if choice >= 1 && choice <= len(options) {
+			fmt.Printf("%s Selected: %s\n\n", white("✓"), cyan(options[choice-1].Name))
+			return options[choice-1].Type
+		}
+		fmt.Printf("%s Please enter a number between 1 and %d: ", yellow("!"), len(options))
+	}
+}
+
+// renderTemplate renders a text template with the given data.
+func renderTemplate(name string, content string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,57 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureFrontendProxy_NextJS(t *testing.T) {
+	dir := t.TempDir()
+	config := "import type { NextConfig } from \"next\";\n\nconst nextConfig: NextConfig = {\n  /* config options here */\n};\n\nexport default nextConfig;\n"
+	configPath := filepath.Join(dir, "next.config.ts")
+	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ConfigureFrontendProxy(dir, NextJS, NPM); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, snippet := range []string{"source: '/api/:path*'", "http://localhost:8080'}/:path*`", "  },\n};"} {
+		if !strings.Contains(string(content), snippet) {
+			t.Errorf("expected next.config.ts to contain %q, got:\n%s", snippet, content)
+		}
+	}
+}
+
+func TestFullStackProject_WriteRootFiles(t *testing.T) {
+	dir := t.TempDir()
+	project := &FullStackProject{Name: "shop", Dir: dir, Frontend: ViteReact, PackageManager: PNPM}
+
+	if err := project.writeRootFiles(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := map[string][]string{
+		"README.md":    {"# shop", "React (Vite) frontend served on port 5173"},
+		"Makefile":     {"cd web && pnpm install", "cd web && pnpm run dev"},
+		"compose.yaml": {`command: sh -c "corepack enable && pnpm install && pnpm run dev"`, "API_URL: http://api:8080"},
+	}
+	for name, snippets := range expected {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		for _, snippet := range snippets {
+			if !strings.Contains(string(content), snippet) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, snippet, content)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -18,6 +19,41 @@ const (
 	WebGo   GoProjectType = "web"
 )
 
+// RoutesMarker marks the place in internal/routes/routes.go where generators
+// register additional routes.
+const RoutesMarker = "// initiator:routes"
+
+// webRoutesContent is the internal/routes/routes.go file of new web projects.
+const webRoutesContent = `package routes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Register wires every application route onto the Echo instance.
+func Register(e *echo.Echo) {
+	e.GET("/", hello)
+	e.GET("/healthz", healthz)
+	` + RoutesMarker + `
+}
+
+// hello returns a JSON welcome message.
+func hello(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Welcome to the API!",
+	})
+}
+
+// healthz reports that the server is up, for container health checks and probes.
+func healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "ok",
+	})
+}
+`
+
 // GoProject represents a Go project.
 type GoProject struct {
 	Name        string
@@ -225,8 +261,11 @@ import (
 	"log"
 )
 
+// version is set at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
-	log.Println("Starting application...")
+	log.Printf("Starting application %s...", version)
 	fmt.Println("Hello from Go!")
 }
 `
@@ -239,24 +278,33 @@ func main() {
 }
 
 // createWebPackage initializes a basic web application structure by creating a main.go file
-// in the cmd directory. The generated file sets up an Echo web server with basic middleware
-// (Logger and Recover) and a single "/" route that returns a JSON welcome message.
-// The server listens on port 8080.
+// in the cmd directory and a routes.go file in internal/routes. The generated main.go sets up
+// an Echo web server with basic middleware (Logger and Recover) and delegates route
+// registration to routes.Register, which serves a "/" route returning a JSON welcome
+// message and a "/healthz" route for health checks. The server listens on port 8080.
 //
-// The function writes the content to cmd/main.go with 0644 permissions.
+// routes.go contains a marker comment that generators such as the OpenAPI scaffolder
+// use to register additional routes.
 //
 // Returns an error if file creation fails.
 func (p *GoProject) createWebPackage() error {
-	mainContent := `package main
+	mainContent := fmt.Sprintf(`package main
 
 import (
-	"net/http"
-	
+	"log"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+
+	"%s/internal/routes"
 )
 
+// version is set at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
+	log.Printf("Starting server %%s", version)
+
 	e := echo.New()
 
 	// Middleware
@@ -264,27 +312,63 @@ func main() {
 	e.Use(middleware.Recover())
 
 	// Routes
-	e.GET("/", hello)
+	routes.Register(e)
 
 	// Start server
 	e.Logger.Fatal(e.Start(":8080"))
 }
-
-// Handler
-func hello(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Welcome to the API!",
-	})
-}
-`
+`, p.Name)
 
 	if err := os.WriteFile("cmd/main.go", []byte(mainContent), 0644); err != nil {
 		return fmt.Errorf("failed to create main.go: %v", err)
 	}
 
+	if err := os.WriteFile("internal/routes/routes.go", []byte(webRoutesContent), 0644); err != nil {
+		return fmt.Errorf("failed to create routes.go: %v", err)
+	}
+
 	return nil
 }
 
+// RegisterGoRoute adds a call to a route registration function to routes.Register
+// in internal/routes/routes.go of a Go web project. Projects created before routes.go
+// existed get a minimal routes.go that still has to be called from main.
+//
+// Parameters:
+//   - projectDir: The root directory of the Go web project
+//   - call: The statement to add, e.g. "registerUserRoutes(e)"
+//
+// Returns:
+//   - string: A manual step the user still has to perform, or an empty string
+//   - error: An error if routes.go cannot be read or written
+func RegisterGoRoute(projectDir string, call string) (string, error) {
+	routesPath := filepath.Join(projectDir, "internal", "routes", "routes.go")
+
+	manual := ""
+	if _, err := os.Stat(routesPath); os.IsNotExist(err) {
+		content := "package routes\n\nimport \"github.com/labstack/echo/v4\"\n\n" +
+			"// Register wires every application route onto the Echo instance.\n" +
+			"func Register(e *echo.Echo) {\n\t" + RoutesMarker + "\n}\n"
+		if err := os.MkdirAll(filepath.Dir(routesPath), 0755); err != nil {
+			return "", fmt.Errorf("failed to create internal/routes directory: %v", err)
+		}
+		if err := os.WriteFile(routesPath, []byte(content), 0644); err != nil {
+			return "", fmt.Errorf("failed to create routes.go: %v", err)
+		}
+		manual = "call routes.Register(e) from cmd/main.go to serve the generated routes"
+	}
+
+	found, err := utils.InsertBeforeLine(routesPath, RoutesMarker, call)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return fmt.Sprintf("call %s from routes.Register in internal/routes/routes.go", call), nil
+	}
+
+	return manual, nil
+}
+
 // installWebDependencies installs required web development dependencies using go get
 // and creates a default .env file with basic configuration.
 //
@@ -1,9 +1,10 @@
 package projects
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -15,6 +16,10 @@ type NodeProject struct {
 	Name        string
 	Dir         string
 	ProjectType NodeProjectType
+	// PackageManager installs the dependencies, prompted for when empty
+	PackageManager PackageManager
+	// Addons are the optional features added on top of the template
+	Addons []NodeAddon
 }
 
 // Create initializes a new Node.js project in the specified directory.
@@ -31,6 +36,12 @@ func (p *NodeProject) Create() error {
 	if p.ProjectType == "" {
 		p.ProjectType = promptNodeProjectType()
 	}
+	if p.PackageManager == "" {
+		p.PackageManager = promptPackageManager()
+	}
+	if p.Addons == nil {
+		p.Addons = promptNodeAddons(availableAddons(p.ProjectType))
+	}
 
 	fmt.Printf("\n📦 Creating new Node.js project: %s (%s)\n\n",
 		cyan(p.Name),
@@ -55,6 +66,10 @@ func (p *NodeProject) Create() error {
 		steps = p.getExpressSteps()
 	case NestJS:
 		steps = p.getNestJSSteps()
+	case Fastify:
+		steps = p.getFastifySteps()
+	case ViteReact, ViteVue, ViteSvelte, ViteSolid:
+		steps = p.getViteSteps()
 	default:
 		return fmt.Errorf("%s Unsupported project type: %s", red("✘"), p.ProjectType)
 	}
@@ -83,8 +98,7 @@ func (p *NodeProject) getTypeScriptSteps() []ProjectSteps {
 		{
 			Name: "Initialize Node.js project",
 			Action: func() error {
-				cmd := exec.Command("npm", "init", "-y")
-				return cmd.Run()
+				return p.PackageManager.Init().Run()
 			},
 			Message: "Node.js project initialized",
 		},
@@ -111,7 +125,7 @@ func (p *NodeProject) getNextJSSteps() []ProjectSteps {
 		{
 			Name: "Creating Next.js project",
 			Action: func() error {
-				return SetupNextJS(p.Name)
+				return SetupNextJS(p.Name, p.PackageManager)
 			},
 			Message: "Next.js project created",
 		},
@@ -124,7 +138,7 @@ func (p *NodeProject) getRemixSteps() []ProjectSteps {
 		{
 			Name: "Creating Remix project",
 			Action: func() error {
-				return SetupRemix(p.Name)
+				return SetupRemix(p.Name, p.PackageManager)
 			},
 			Message: "Remix project created",
 		},
@@ -137,8 +151,7 @@ func (p *NodeProject) getExpressSteps() []ProjectSteps {
 		{
 			Name: "Initialize Node.js project",
 			Action: func() error {
-				cmd := exec.Command("npm", "init", "-y")
-				return cmd.Run()
+				return p.PackageManager.Init().Run()
 			},
 			Message: "Node.js project initialized",
 		},
@@ -152,7 +165,7 @@ func (p *NodeProject) getExpressSteps() []ProjectSteps {
 		{
 			Name: "Setup Express",
 			Action: func() error {
-				return SetupExpress(p.Name)
+				return SetupExpress(p.Name, p.PackageManager)
 			},
 			Message: "Express.js installed",
 		},
@@ -169,6 +182,10 @@ app.get('/', (req, res) => {
   res.send('Hello from Express with TypeScript!');
 });
 
+app.get('/healthz', (req, res) => {
+  res.json({ status: 'ok' });
+});
+
 app.listen(port, () => {
   console.log(\"Server running on port \${port}\");
 });`
@@ -179,19 +196,116 @@ app.listen(port, () => {
 	}
 }
 
+// getFastifySteps returns the steps needed to set up a Fastify project
+func (p *NodeProject) getFastifySteps() []ProjectSteps {
+	return []ProjectSteps{
+		{
+			Name: "Initialize Node.js project",
+			Action: func() error {
+				return p.PackageManager.Init().Run()
+			},
+			Message: "Node.js project initialized",
+		},
+		{
+			Name: "Setup TypeScript",
+			Action: func() error {
+				return p.setupTypeScriptProject()
+			},
+			Message: "TypeScript configuration completed",
+		},
+		{
+			Name: "Setup Fastify",
+			Action: func() error {
+				return SetupFastify(p.Name, p.PackageManager)
+			},
+			Message: "Fastify installed",
+		},
+		{
+			Name: "Create Fastify starter files",
+			Action: func() error {
+				appContent := `import Fastify from 'fastify';
+
+const app = Fastify({ logger: true });
+const port = Number(process.env.PORT) || 3000;
+
+app.get('/', async () => {
+  return { message: 'Hello from Fastify with TypeScript!' };
+});
+
+app.get('/healthz', async () => {
+  return { status: 'ok' };
+});
+
+app.listen({ port, host: '0.0.0.0' }).catch((err) => {
+  app.log.error(err);
+  process.exit(1);
+});
+`
+				return os.WriteFile("src/index.ts", []byte(appContent), 0644)
+			},
+			Message: "Fastify starter files created",
+		},
+	}
+}
+
 // getNestJSSteps returns the steps needed to set up a NestJS project
 func (p *NodeProject) getNestJSSteps() []ProjectSteps {
 	return []ProjectSteps{
 		{
 			Name: "Creating NestJS project",
 			Action: func() error {
-				return SetupNestJS(p.Name)
+				return SetupNestJS(p.Name, p.PackageManager)
 			},
 			Message: "NestJS project created",
 		},
 	}
 }
 
+// getViteSteps returns the steps needed to set up a Vite single page application
+// along with the selected add-ons
+func (p *NodeProject) getViteSteps() []ProjectSteps {
+	steps := []ProjectSteps{
+		{
+			Name: "Creating Vite project",
+			Action: func() error {
+				return SetupVite(p.ProjectType, p.PackageManager)
+			},
+			Message: "Vite project created",
+		},
+	}
+
+	if p.hasAddon(Tailwind) {
+		steps = append(steps, ProjectSteps{
+			Name: "Setup Tailwind CSS",
+			Action: func() error {
+				return SetupViteTailwind(p.ProjectType, p.PackageManager)
+			},
+			Message: "Tailwind CSS configured",
+		})
+	}
+	if p.hasAddon(Router) {
+		steps = append(steps, ProjectSteps{
+			Name: "Setup router",
+			Action: func() error {
+				return SetupViteRouter(p.ProjectType, p.PackageManager)
+			},
+			Message: "Router configured",
+		})
+	}
+
+	return steps
+}
+
+// hasAddon reports whether the add-on was selected for the project
+func (p *NodeProject) hasAddon(addon NodeAddon) bool {
+	for _, a := range p.Addons {
+		if a == addon {
+			return true
+		}
+	}
+	return false
+}
+
 // printProjectInfo prints the project information to the console.
 func (p *NodeProject) printProjectInfo() {
 	// Define color functions
@@ -205,6 +319,7 @@ func (p *NodeProject) printProjectInfo() {
 		{"Project Name", p.Name},
 		{"Location", p.Dir},
 		{"Type", string(p.ProjectType)},
+		{"Package Mgr", string(p.PackageManager)},
 	}
 
 	// Print project information
@@ -219,22 +334,23 @@ func (p *NodeProject) printProjectInfo() {
 	fmt.Println("\nNext steps:")
 	fmt.Printf("  cd %s\n", cyan(p.Name))
 
+	pm := p.PackageManager
 	switch p.ProjectType {
-	case TypeScriptBasic, Express:
-		fmt.Printf("  %s\n", cyan("npm run dev"))
-		fmt.Printf("  %s\n", cyan("npm run build"))
-	case NextJS:
-		fmt.Printf("  %s\n", cyan("npm run dev"))
-		fmt.Printf("  %s\n", cyan("npm run build"))
-		fmt.Printf("  %s\n", cyan("npm start"))
-	case Remix:
-		fmt.Printf("  %s\n", cyan("npm run dev"))
-		fmt.Printf("  %s\n", cyan("npm run build"))
-		fmt.Printf("  %s\n", cyan("npm start"))
+	case TypeScriptBasic, Express, Fastify:
+		fmt.Printf("  %s\n", cyan(pm.Run("dev")))
+		fmt.Printf("  %s\n", cyan(pm.Run("build")))
+	case NextJS, Remix:
+		fmt.Printf("  %s\n", cyan(pm.Run("dev")))
+		fmt.Printf("  %s\n", cyan(pm.Run("build")))
+		fmt.Printf("  %s\n", cyan(pm.Run("start")))
 	case NestJS:
-		fmt.Printf("  %s\n", cyan("npm run start:dev"))
-		fmt.Printf("  %s\n", cyan("npm run build"))
-		fmt.Printf("  %s\n", cyan("npm run start:prod"))
+		fmt.Printf("  %s\n", cyan(pm.Run("start:dev")))
+		fmt.Printf("  %s\n", cyan(pm.Run("build")))
+		fmt.Printf("  %s\n", cyan(pm.Run("start:prod")))
+	case ViteReact, ViteVue, ViteSvelte, ViteSolid:
+		fmt.Printf("  %s\n", cyan(pm.Run("dev")))
+		fmt.Printf("  %s\n", cyan(pm.Run("build")))
+		fmt.Printf("  %s\n", cyan(pm.Run("preview")))
 	}
 	fmt.Println()
 }
@@ -280,8 +396,7 @@ func (p *NodeProject) setupTypeScriptProject() error {
 	// Install TypeScript dependencies with spinner
 	s := utils.CreateSpinner("Installing TypeScript dependencies...")
 	s.Start()
-	cmd := exec.Command("npm", "install", "--save-dev", "typescript", "@types/node", "ts-node")
-	err := cmd.Run()
+	err := p.PackageManager.Add(true, "typescript", "@types/node", "ts-node").Run()
 	s.Stop()
 	if err != nil {
 		return fmt.Errorf("failed to install TypeScript dependencies: %v", err)
@@ -309,6 +424,26 @@ func (p *NodeProject) setupTypeScriptProject() error {
     "test": "echo \\"Error: no test specified\\" && exit 1"
   }`, scripts, 1)
 
+	// Package managers other than npm generate a different package.json,
+	// fall back to rewriting it as JSON
+	if !strings.Contains(pkgJson, `"dev":`) {
+		var pkg map[string]interface{}
+		if err := json.Unmarshal(content, &pkg); err != nil {
+			return fmt.Errorf("failed to parse package.json: %v", err)
+		}
+		pkg["scripts"] = map[string]string{
+			"start": "node dist/index.js",
+			"dev":   "ts-node src/index.ts",
+			"build": "tsc",
+			"watch": "tsc -w",
+		}
+		updated, err := json.MarshalIndent(pkg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to update package.json: %v", err)
+		}
+		pkgJson = string(updated) + "\n"
+	}
+
 	// Write updated package.json
 	if err := os.WriteFile("package.json", []byte(pkgJson), 0644); err != nil {
 		return fmt.Errorf("failed to update package.json: %v", err)
@@ -323,6 +458,39 @@ func (p *NodeProject) installPackages() error {
 	return nil
 }
 
+// RegisterNodeRoute imports a router or plugin in src/index.ts of an Express or Fastify
+// project and registers it right before the server starts listening.
+//
+// Parameters:
+//   - projectDir: The root directory of the Node.js project
+//   - importLine: The import statement of the router, added after the existing imports
+//   - registerLine: The statement registering the router, e.g. "app.use(router);"
+//
+// Returns:
+//   - string: A manual step the user still has to perform, or an empty string
+//   - error: An error if src/index.ts cannot be read or written
+func RegisterNodeRoute(projectDir string, importLine string, registerLine string) (string, error) {
+	indexPath := filepath.Join(projectDir, "src", "index.ts")
+	manual := fmt.Sprintf("add `%s` and `%s` to your server entry point", importLine, registerLine)
+
+	if _, err := os.Stat(indexPath); err != nil {
+		return manual, nil
+	}
+
+	found, err := utils.InsertBeforeLine(indexPath, "app.listen(", registerLine+"\n")
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return manual, nil
+	}
+
+	if err := utils.InsertAfterLastLine(indexPath, "import ", importLine); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
 // promptNodeProjectType prompts the user to select a Node.js project type
 func promptNodeProjectType() NodeProjectType {
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -351,3 +519,49 @@ func promptNodeProjectType() NodeProjectType {
 		fmt.Printf("%s Please enter a number between 1 and %d: ", yellow("!"), len(options))
 	}
 }
+
+// promptNodeAddons prompts the user to select the add-ons of a Node.js project,
+// it returns without prompting when the project type has no add-ons
+func promptNodeAddons(addons []NodeAddon) []NodeAddon {
+	if len(addons) == 0 {
+		return []NodeAddon{}
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	white := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	fmt.Printf("\n%s Select add-ons (comma separated, empty for none):\n\n", white("📋"))
+	for i, addon := range addons {
+		fmt.Printf("%s %s\n", cyan(fmt.Sprintf("%d.", i+1)), addon)
+	}
+	fmt.Printf("\n%s Enter your choices: ", white("→"))
+
+	for {
+		var input string
+		fmt.Scanln(&input)
+
+		selected, ok := parseAddonChoices(input, addons)
+		if ok {
+			return selected
+		}
+		fmt.Printf("%s Please enter numbers between 1 and %d separated by commas: ", yellow("!"), len(addons))
+	}
+}
+
+// parseAddonChoices converts a comma separated list of menu numbers into add-ons
+func parseAddonChoices(input string, addons []NodeAddon) ([]NodeAddon, bool) {
+	selected := []NodeAddon{}
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var choice int
+		if _, err := fmt.Sscanf(part, "%d", &choice); err != nil || choice < 1 || choice > len(addons) {
+			return nil, false
+		}
+		selected = append(selected, addons[choice-1])
+	}
+	return selected, true
+}
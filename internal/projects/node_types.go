@@ -1,9 +1,5 @@
 package projects
 
-import (
-	"os/exec"
-)
-
 // NodeProjectType represents the type of Node.js project
 type NodeProjectType string
 
@@ -14,6 +10,11 @@ const (
 	Remix           NodeProjectType = "remix"
 	Express         NodeProjectType = "express"
 	NestJS          NodeProjectType = "nestjs"
+	Fastify         NodeProjectType = "fastify"
+	ViteReact       NodeProjectType = "vite-react"
+	ViteVue         NodeProjectType = "vite-vue"
+	ViteSvelte      NodeProjectType = "vite-svelte"
+	ViteSolid       NodeProjectType = "vite-solid"
 )
 
 // NodeProjectOption represents a Node.js project option in the selection menu
@@ -51,28 +52,53 @@ func GetNodeProjectOptions() []NodeProjectOption {
 			Name:        "NestJS",
 			Description: "Progressive Node.js framework for building server-side applications",
 		},
+		{
+			Type:        Fastify,
+			Name:        "Fastify",
+			Description: "Fast and low overhead web framework with schema-based validation",
+		},
+		{
+			Type:        ViteReact,
+			Name:        "React (Vite)",
+			Description: "React single page application built with Vite",
+		},
+		{
+			Type:        ViteVue,
+			Name:        "Vue (Vite)",
+			Description: "Vue single page application built with Vite",
+		},
+		{
+			Type:        ViteSvelte,
+			Name:        "Svelte (Vite)",
+			Description: "Svelte single page application built with Vite",
+		},
+		{
+			Type:        ViteSolid,
+			Name:        "Solid (Vite)",
+			Description: "Solid single page application built with Vite",
+		},
 	}
 }
 
 // SetupNextJS configures a Next.js project
-func SetupNextJS(name string) error {
-	cmd := exec.Command("npx", "create-next-app@latest", ".", "--typescript", "--eslint", "--tailwind", "--app", "--src-dir", "--import-alias", "@/*", "--use-npm")
-	cmd.Env = append(cmd.Environ(), "npm_config_yes=true")
+func SetupNextJS(name string, pm PackageManager) error {
+	cmd := pm.Exec("create-next-app@latest", ".", "--typescript", "--eslint", "--tailwind", "--app", "--src-dir", "--import-alias", "@/*", "--use-"+string(pm))
 	return cmd.Run()
 }
 
 // SetupRemix configures a Remix project
-func SetupRemix(name string) error {
-	cmd := exec.Command("npx", "create-remix@latest", ".", "--typescript", "--install")
-	cmd.Env = append(cmd.Environ(), "npm_config_yes=true")
+func SetupRemix(name string, pm PackageManager) error {
+	cmd := pm.Exec("create-remix@latest", ".", "--typescript", "--install", "--package-manager", string(pm))
 	return cmd.Run()
 }
 
 // SetupExpress configures an Express.js project with TypeScript
-func SetupExpress(name string) error {
+func SetupExpress(name string, pm PackageManager) error {
 	// Install dependencies
-	installCmd := exec.Command("npm", "install", "express", "@types/express", "--save")
-	if err := installCmd.Run(); err != nil {
+	if err := pm.Add(false, "express").Run(); err != nil {
+		return err
+	}
+	if err := pm.Add(true, "@types/express").Run(); err != nil {
 		return err
 	}
 
@@ -81,9 +107,17 @@ func SetupExpress(name string) error {
 	return nil
 }
 
+// SetupFastify configures a Fastify project with TypeScript
+func SetupFastify(name string, pm PackageManager) error {
+	return pm.Add(false, "fastify").Run()
+}
+
 // SetupNestJS configures a NestJS project
-func SetupNestJS(name string) error {
-	cmd := exec.Command("npx", "@nestjs/cli", "new", ".", "--package-manager", "npm", "--language", "ts")
-	cmd.Env = append(cmd.Environ(), "npm_config_yes=true")
+func SetupNestJS(name string, pm PackageManager) error {
+	// The Nest CLI only knows npm, yarn and pnpm
+	if pm == Bun {
+		pm = NPM
+	}
+	cmd := NPM.Exec("@nestjs/cli", "new", ".", "--package-manager", string(pm), "--language", "ts")
 	return cmd.Run()
 }
@@ -0,0 +1,213 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/fatih/color"
+)
+
+// PackageManager represents a Node.js package manager.
+type PackageManager string
+
+const (
+	NPM  PackageManager = "npm"
+	PNPM PackageManager = "pnpm"
+	Yarn PackageManager = "yarn"
+	Bun  PackageManager = "bun"
+)
+
+// OrDefault returns the package manager, npm when it is not set.
+func (pm PackageManager) OrDefault() PackageManager {
+	if pm == "" {
+		return NPM
+	}
+	return pm
+}
+
+// Command returns a command running the package manager with the given arguments.
+func (pm PackageManager) Command(args ...string) *exec.Cmd {
+	cmd := exec.Command(string(pm), args...)
+	cmd.Env = append(cmd.Environ(), "npm_config_yes=true")
+	return cmd
+}
+
+// Init returns a command that creates a package.json without prompting.
+func (pm PackageManager) Init() *exec.Cmd {
+	if pm == PNPM {
+		return pm.Command("init")
+	}
+	return pm.Command("init", "-y")
+}
+
+// Install returns a command that installs all dependencies listed in package.json.
+func (pm PackageManager) Install() *exec.Cmd {
+	return pm.Command("install")
+}
+
+// InstallFrozen returns a command that installs the exact versions of the lockfile
+// and fails when it is out of date, as used in CI.
+func (pm PackageManager) InstallFrozen() *exec.Cmd {
+	if pm == NPM {
+		return pm.Command("ci")
+	}
+	return pm.Command("install", "--frozen-lockfile")
+}
+
+// InstallProduction returns a command like InstallFrozen that leaves out the dev
+// dependencies, as used in production images.
+func (pm PackageManager) InstallProduction() *exec.Cmd {
+	switch pm {
+	case NPM:
+		return pm.Command("ci", "--omit=dev")
+	case PNPM:
+		return pm.Command("install", "--frozen-lockfile", "--prod")
+	default:
+		return pm.Command("install", "--frozen-lockfile", "--production")
+	}
+}
+
+// Lockfile returns the name of the lockfile written by the package manager.
+func (pm PackageManager) Lockfile() string {
+	return pm.Lockfiles()[0]
+}
+
+// Lockfiles returns the names of the lockfiles the package manager reads, the one
+// it writes first. Bun still reads the binary bun.lockb of Bun 1.1 and earlier.
+func (pm PackageManager) Lockfiles() []string {
+	switch pm {
+	case PNPM:
+		return []string{"pnpm-lock.yaml"}
+	case Yarn:
+		return []string{"yarn.lock"}
+	case Bun:
+		return []string{"bun.lock", "bun.lockb"}
+	default:
+		return []string{"package-lock.json"}
+	}
+}
+
+// LockfileIn returns the lockfile of the package manager that exists in dir,
+// falling back to the one it writes.
+func (pm PackageManager) LockfileIn(dir string) string {
+	for _, name := range pm.Lockfiles() {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return name
+		}
+	}
+	return pm.Lockfile()
+}
+
+// Add returns a command that adds packages as dependencies, or as dev dependencies when dev is true.
+func (pm PackageManager) Add(dev bool, packages ...string) *exec.Cmd {
+	var args []string
+	switch pm {
+	case NPM:
+		args = []string{"install"}
+		if dev {
+			args = append(args, "--save-dev")
+		} else {
+			args = append(args, "--save")
+		}
+	case Bun:
+		args = []string{"add"}
+		if dev {
+			args = append(args, "--dev")
+		}
+	default:
+		args = []string{"add"}
+		if dev {
+			args = append(args, "-D")
+		}
+	}
+	return pm.Command(append(args, packages...)...)
+}
+
+// Create returns a command that runs a create-* starter kit, e.g. Create("vite@latest", ".")
+// runs "npm create vite@latest ." with npm.
+func (pm PackageManager) Create(starter string, args ...string) *exec.Cmd {
+	cmdArgs := []string{"create", starter}
+	if pm == NPM && len(args) > 0 {
+		// npm needs "--" to forward flags to the starter kit
+		cmdArgs = append(cmdArgs, args[0], "--")
+		args = args[1:]
+	}
+	return pm.Command(append(cmdArgs, args...)...)
+}
+
+// Exec returns a command that downloads and runs a package binary, like npx does for npm.
+func (pm PackageManager) Exec(pkg string, args ...string) *exec.Cmd {
+	var cmd *exec.Cmd
+	switch pm {
+	case PNPM:
+		cmd = exec.Command("pnpm", append([]string{"dlx", pkg}, args...)...)
+	case Yarn:
+		cmd = exec.Command("yarn", append([]string{"dlx", pkg}, args...)...)
+	case Bun:
+		cmd = exec.Command("bunx", append([]string{pkg}, args...)...)
+	default:
+		cmd = exec.Command("npx", append([]string{pkg}, args...)...)
+	}
+	cmd.Env = append(cmd.Environ(), "npm_config_yes=true")
+	return cmd
+}
+
+// Run returns the command line that runs a package.json script, e.g. "pnpm run dev".
+func (pm PackageManager) Run(script string) string {
+	if pm == NPM && script == "start" {
+		return "npm start"
+	}
+	return fmt.Sprintf("%s run %s", pm, script)
+}
+
+// IsInstalled reports whether the package manager binary is available.
+func (pm PackageManager) IsInstalled() bool {
+	_, err := exec.LookPath(string(pm))
+	return err == nil
+}
+
+// DetectPackageManager returns the package manager whose lockfile exists in dir,
+// falling back to npm.
+func DetectPackageManager(dir string) PackageManager {
+	for _, pm := range []PackageManager{PNPM, Yarn, Bun} {
+		for _, name := range pm.Lockfiles() {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return pm
+			}
+		}
+	}
+	return NPM
+}
+
+// promptPackageManager prompts the user to select the package manager of a Node.js project.
+func promptPackageManager() PackageManager {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	white := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	options := []PackageManager{NPM, PNPM, Yarn, Bun}
+
+	fmt.Printf("\n%s Select a package manager:\n\n", white("📋"))
+
+	for i, opt := range options {
+		status := ""
+		if !opt.IsInstalled() {
+			status = yellow(" (not installed)")
+		}
+		fmt.Printf("%s %s%s\n", cyan(fmt.Sprintf("%d.", i+1)), opt, status)
+	}
+
+	fmt.Printf("\n%s Enter your choice (1-%d): ", white("→"), len(options))
+
+	var choice int
+	for {
+		fmt.Scanln(&choice)
+		if choice >= 1 && choice <= len(options) {
+			fmt.Printf("%s Selected: %s\n\n", white("✓"), cyan(options[choice-1]))
+			return options[choice-1]
+		}
+		fmt.Printf("%s Please enter a number between 1 and %d: ", yellow("!"), len(options))
+	}
+}
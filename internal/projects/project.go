@@ -23,14 +23,16 @@ type ProjectSteps struct {
 type ProjectType string
 
 const (
-	NodeJS ProjectType = "nodejs"
-	GoLang ProjectType = "golang"
+	NodeJS    ProjectType = "nodejs"
+	GoLang    ProjectType = "golang"
+	FullStack ProjectType = "fullstack"
 )
 
 // NewProject creates and returns a new Project instance based on the specified project type.
 // It takes the project name, directory path, and project type as parameters.
 // For NodeJS projects, it returns a NodeProject instance.
 // For GoLang projects, it returns a GoProject instance.
+// For FullStack projects, it returns a FullStackProject instance.
 // Returns nil for unsupported project types.
 func NewProject(name string, dir string, projectType ProjectType) Project {
 	switch projectType {
@@ -46,6 +48,12 @@ func NewProject(name string, dir string, projectType ProjectType) Project {
 			Dir:         dir,
 			ProjectType: "", // Will prompt user during creation
 		}
+	case FullStack:
+		return &FullStackProject{
+			Name:     name,
+			Dir:      dir,
+			Frontend: "", // Will prompt user during creation
+		}
 	default:
 		return nil
 	}
@@ -76,12 +84,13 @@ type projectOption struct {
 // It continuously asks for input until a valid project type is selected.
 // The function reads user input from standard input and converts it to lowercase for comparison.
 // Returns:
-//   - ProjectType: The selected project type (either NodeJS or GoLang)
+//   - ProjectType: The selected project type (NodeJS, GoLang or FullStack)
 //   - If an error occurs while reading input, returns an empty ProjectType
 //
 // Valid inputs are:
 //   - "nodejs" for NodeJS projects
 //   - "golang" for GoLang projects
+//   - "fullstack" for FullStack projects
 func PromptUserForProjectType() ProjectType {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
@@ -98,6 +107,11 @@ func PromptUserForProjectType() ProjectType {
 			Name:        "Go",
 			Description: "Create a Go project with modern project structure",
 		},
+		{
+			Type:        FullStack,
+			Name:        "Full-stack (Go + Node.js)",
+			Description: "Create a Go Echo API in api/ and a Next.js or Vite frontend in web/",
+		},
 	}
 
 	fmt.Printf("\n%s Select a project type:\n\n", white("📋"))
@@ -39,6 +39,11 @@ func TestNewProject(t *testing.T) {
 		t.Fatalf("expected GoProject, got %T", goProject)
 	}
 
+	fullStackProject := NewProject(projectName, projectDir, FullStack)
+	if _, ok := fullStackProject.(*FullStackProject); !ok {
+		t.Fatalf("expected FullStackProject, got %T", fullStackProject)
+	}
+
 	unknownProject := NewProject(projectName, projectDir, "unknown")
 	if unknownProject != nil {
 		t.Fatalf("expected nil, got %T", unknownProject)
@@ -0,0 +1,219 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// NodeAddon represents an optional feature added on top of a Node.js project template.
+type NodeAddon string
+
+const (
+	Tailwind NodeAddon = "tailwind"
+	Router   NodeAddon = "router"
+)
+
+// viteFramework describes how a Vite single page application variant is scaffolded.
+type viteFramework struct {
+	// Template is the create-vite template name
+	Template string
+	// Entry is the application entry point created by the template
+	Entry string
+	// Stylesheet is the global stylesheet imported by the entry point
+	Stylesheet string
+	// RouterPackage is the client side router installed by the router add-on
+	RouterPackage string
+	// RouterEntry replaces the entry point when the router add-on is selected
+	RouterEntry string
+	// RouterFiles are additional files written by the router add-on
+	RouterFiles map[string]string
+}
+
+// viteFrameworks maps the Vite project types to their scaffolding details.
+var viteFrameworks = map[NodeProjectType]viteFramework{
+	ViteReact: {
+		Template:      "react-ts",
+		Entry:         "src/main.tsx",
+		Stylesheet:    "src/index.css",
+		RouterPackage: "react-router",
+		RouterEntry: `import { StrictMode } from 'react'
+import { createRoot } from 'react-dom/client'
+import { createBrowserRouter, RouterProvider } from 'react-router'
+import './index.css'
+import App from './App.tsx'
+
+const router = createBrowserRouter([
+  { path: '/', element: <App /> },
+])
+
+createRoot(document.getElementById('root')!).render(
+  <StrictMode>
+    <RouterProvider router={router} />
+  </StrictMode>,
+)
+`,
+	},
+	ViteVue: {
+		Template:      "vue-ts",
+		Entry:         "src/main.ts",
+		Stylesheet:    "src/style.css",
+		RouterPackage: "vue-router",
+		RouterEntry: `import { createApp } from 'vue'
+import { createRouter, createWebHistory, RouterView } from 'vue-router'
+import './style.css'
+
+const router = createRouter({
+  history: createWebHistory(),
+  routes: [
+    { path: '/', component: () => import('./App.vue') },
+  ],
+})
+
+createApp(RouterView).use(router).mount('#app')
+`,
+	},
+	ViteSvelte: {
+		Template:      "svelte-ts",
+		Entry:         "src/main.ts",
+		Stylesheet:    "src/app.css",
+		RouterPackage: "svelte-spa-router",
+		RouterEntry: `import { mount } from 'svelte'
+import './app.css'
+import Routes from './Routes.svelte'
+
+const app = mount(Routes, {
+  target: document.getElementById('app')!,
+})
+
+export default app
+`,
+		RouterFiles: map[string]string{
+			"src/Routes.svelte": `<script lang="ts">
+  import Router from 'svelte-spa-router'
+  import App from './App.svelte'
+
+  const routes = {
+    '/': App,
+  }
+</script>
+
+<Router {routes} />
+`,
+		},
+	},
+	ViteSolid: {
+		Template:      "solid-ts",
+		Entry:         "src/index.tsx",
+		Stylesheet:    "src/index.css",
+		RouterPackage: "@solidjs/router",
+		RouterEntry: `/* @refresh reload */
+import { render } from 'solid-js/web'
+import { Router, Route } from '@solidjs/router'
+import './index.css'
+import App from './App.tsx'
+
+const root = document.getElementById('root')
+
+render(
+  () => (
+    <Router>
+      <Route path="/" component={App} />
+    </Router>
+  ),
+  root!,
+)
+`,
+	},
+}
+
+// IsVite reports whether the project type is a Vite single page application.
+func (t NodeProjectType) IsVite() bool {
+	_, ok := viteFrameworks[t]
+	return ok
+}
+
+// availableAddons returns the add-ons offered for a Node.js project type.
+func availableAddons(projectType NodeProjectType) []NodeAddon {
+	if projectType.IsVite() {
+		return []NodeAddon{Tailwind, Router}
+	}
+	return nil
+}
+
+// SetupVite scaffolds a Vite single page application with the TypeScript template
+// of the given framework and installs its dependencies.
+func SetupVite(projectType NodeProjectType, pm PackageManager) error {
+	framework, ok := viteFrameworks[projectType]
+	if !ok {
+		return fmt.Errorf("%s is not a Vite project type", projectType)
+	}
+
+	cmd := pm.Create("vite@latest", ".", "--template", framework.Template, "--no-interactive")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("create-vite failed: %v - %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return pm.Install().Run()
+}
+
+// SetupViteTailwind adds Tailwind CSS through its Vite plugin: it installs the packages,
+// registers the plugin in vite.config.ts and imports Tailwind in the global stylesheet.
+func SetupViteTailwind(projectType NodeProjectType, pm PackageManager) error {
+	framework := viteFrameworks[projectType]
+
+	if err := pm.Add(true, "tailwindcss", "@tailwindcss/vite").Run(); err != nil {
+		return fmt.Errorf("failed to install Tailwind CSS: %v", err)
+	}
+
+	content, err := os.ReadFile("vite.config.ts")
+	if err != nil {
+		return fmt.Errorf("failed to read vite.config.ts: %v", err)
+	}
+	config := string(content)
+	if !strings.Contains(config, "plugins: [") {
+		return fmt.Errorf("vite.config.ts has no plugins list to register Tailwind CSS in")
+	}
+	config = strings.Replace(config, "plugins: [", "plugins: [tailwindcss(), ", 1)
+	if err := os.WriteFile("vite.config.ts", []byte(config), 0644); err != nil {
+		return fmt.Errorf("failed to update vite.config.ts: %v", err)
+	}
+	if err := utils.InsertAfterLastLine("vite.config.ts", "import ", "import tailwindcss from '@tailwindcss/vite'"); err != nil {
+		return err
+	}
+
+	stylesheet, err := os.ReadFile(framework.Stylesheet)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", framework.Stylesheet, err)
+	}
+	stylesheet = append([]byte("@import \"tailwindcss\";\n\n"), stylesheet...)
+	if err := os.WriteFile(framework.Stylesheet, stylesheet, 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %v", framework.Stylesheet, err)
+	}
+
+	return nil
+}
+
+// SetupViteRouter installs the idiomatic client side router of the framework and
+// mounts the application component on the "/" route.
+func SetupViteRouter(projectType NodeProjectType, pm PackageManager) error {
+	framework := viteFrameworks[projectType]
+
+	if err := pm.Add(false, framework.RouterPackage).Run(); err != nil {
+		return fmt.Errorf("failed to install %s: %v", framework.RouterPackage, err)
+	}
+
+	for path, content := range framework.RouterFiles {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", path, err)
+		}
+	}
+
+	if err := os.WriteFile(framework.Entry, []byte(framework.RouterEntry), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %v", framework.Entry, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package quality
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// HookManager is the tool running the Git hooks
+type HookManager string
+
+const (
+	PreCommit HookManager = "pre-commit"
+	Lefthook  HookManager = "lefthook"
+)
+
+// ParseHookManager validates a hook manager name
+func ParseHookManager(name string) (HookManager, error) {
+	switch manager := HookManager(strings.ToLower(name)); manager {
+	case PreCommit, Lefthook:
+		return manager, nil
+	default:
+		return "", fmt.Errorf("unsupported hook manager %q, use %s or %s", name, PreCommit, Lefthook)
+	}
+}
+
+// Hook is a pre-commit check run for one project
+type Hook struct {
+	ID   string
+	Name string
+	// Root is the project directory relative to the repository, empty for the root
+	Root    string
+	Command string
+	// Glob selects the staged files that trigger the hook, for Lefthook
+	Glob string
+	// Files selects the staged files that trigger the hook, for pre-commit
+	Files string
+}
+
+// Entry returns the pre-commit entry, which always runs from the repository root
+func (h Hook) Entry() string {
+	if h.Root == "" {
+		return h.Command
+	}
+	return fmt.Sprintf("sh -c 'cd %s && %s'", strings.TrimSuffix(h.Root, "/"), h.Command)
+}
+
+// Script is a package.json script added to Node.js projects
+type Script struct {
+	Name    string
+	Command string
+}
+
+// formatScripts format and check Node.js projects with Prettier
+var formatScripts = []Script{
+	{Name: "format", Command: "prettier --write ."},
+	{Name: "format:check", Command: "prettier --check ."},
+}
+
+// nodeExtensions are the file extensions checked by the Node.js hooks
+var nodeExtensions = []string{"js", "jsx", "ts", "tsx", "vue", "svelte", "css", "json", "md"}
+
+// Generator writes the linter, formatter and Git hook configuration of a project.
+// Full-stack projects pass the API and the frontend as separate projects and get a
+// single hook configuration at the repository root.
+type Generator struct {
+	Dir      string
+	Projects []*projects.ProjectInfo
+	Hooks    HookManager
+	// Force overwrites existing configuration files
+	Force bool
+	// AddedScripts lists the package.json scripts added to Node.js projects
+	AddedScripts []string
+	// Warnings lists steps the user has to complete by hand
+	Warnings []string
+}
+
+// NewGenerator creates a new Generator for the projects of dir
+func NewGenerator(dir string, apps []*projects.ProjectInfo, hooks HookManager, force bool) *Generator {
+	return &Generator{
+		Dir:      dir,
+		Projects: apps,
+		Hooks:    hooks,
+		Force:    force,
+	}
+}
+
+// Generate writes the configuration files, adds the format and lint scripts that
+// Node.js projects are missing and records the manual steps in Warnings.
+// It refuses to overwrite existing files unless Force is set.
+func (g *Generator) Generate() error {
+	files, err := g.Files()
+	if err != nil {
+		return err
+	}
+
+	if !g.Force {
+		for name := range files {
+			if _, err := os.Stat(filepath.Join(g.Dir, name)); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(g.Dir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %v", name, err)
+		}
+	}
+
+	for _, project := range g.Projects {
+		if project.Type == projects.GoLang {
+			g.checkTaskFiles(project)
+			continue
+		}
+		if err := g.addScripts(project); err != nil {
+			return err
+		}
+		install := strings.Join(project.PackageManager.OrDefault().Add(true, "prettier").Args, " ")
+		if rel := project.RelativePath(g.Dir); rel != "." {
+			install = "cd " + rel + " && " + install
+		}
+		g.Warnings = append(g.Warnings, "install Prettier with: "+install)
+	}
+
+	g.Warnings = append(g.Warnings, fmt.Sprintf("install the Git hooks with: %s install", g.Hooks))
+	return nil
+}
+
+// Files returns the generated files keyed by their path relative to Dir:
+// .editorconfig, .golangci.yml for Go projects, the Prettier configuration for
+// Node.js projects and the hook manager configuration.
+func (g *Generator) Files() (map[string]string, error) {
+	files := map[string]string{}
+
+	editorconfig, err := render("editorconfig", editorconfigTemplate, struct{ Go bool }{projects.HasProjectType(g.Projects, projects.GoLang)})
+	if err != nil {
+		return nil, err
+	}
+	files[".editorconfig"] = editorconfig
+
+	for _, project := range g.Projects {
+		rel := project.RelativePath(g.Dir)
+		if project.Type == projects.GoLang {
+			files[filepath.Join(rel, ".golangci.yml")] = golangciTemplate
+		} else {
+			files[filepath.Join(rel, ".prettierrc.json")] = prettierrcTemplate
+			files[filepath.Join(rel, ".prettierignore")] = prettierignoreTemplate
+		}
+	}
+
+	name, content := ".pre-commit-config.yaml", preCommitTemplate
+	if g.Hooks == Lefthook {
+		name, content = "lefthook.yml", lefthookTemplate
+	}
+	hooks, err := render(name, content, struct{ Hooks []Hook }{g.GitHooks()})
+	if err != nil {
+		return nil, err
+	}
+	files[name] = hooks
+
+	return files, nil
+}
+
+// GitHooks returns the pre-commit checks: golangci-lint for Go projects, and
+// Prettier and the lint script for Node.js projects
+func (g *Generator) GitHooks() []Hook {
+	var hooks []Hook
+	for _, project := range g.Projects {
+		rel := project.RelativePath(g.Dir)
+		root, prefix, suffix := "", "", ""
+		if rel != "." {
+			root, prefix, suffix = rel+"/", "^"+rel+"/.*", "-"+rel
+		}
+
+		if project.Type == projects.GoLang {
+			hooks = append(hooks, Hook{
+				ID:      "golangci-lint" + suffix,
+				Name:    "golangci-lint",
+				Root:    root,
+				Command: "golangci-lint run",
+				Glob:    "*.go",
+				Files:   prefix + `\.go$`,
+			})
+			continue
+		}
+
+		pm := project.PackageManager.OrDefault()
+		hooks = append(hooks, Hook{
+			ID:      "prettier" + suffix,
+			Name:    "prettier",
+			Root:    root,
+			Command: pm.Run("format:check"),
+			Glob:    "*.{" + strings.Join(nodeExtensions, ",") + "}",
+			Files:   prefix + `\.(` + strings.Join(nodeExtensions, "|") + `)$`,
+		})
+		if project.HasScript("lint") || g.lintScript(project) != "" {
+			hooks = append(hooks, Hook{
+				ID:      "lint" + suffix,
+				Name:    "lint",
+				Root:    root,
+				Command: pm.Run("lint"),
+				Glob:    "*.{js,jsx,ts,tsx,vue,svelte}",
+				Files:   prefix + `\.(js|jsx|ts|tsx|vue|svelte)$`,
+			})
+		}
+	}
+	return hooks
+}
+
+// addScripts adds the format scripts, and a type checking lint script when the
+// project has none, keeping the scripts the project already defines
+func (g *Generator) addScripts(project *projects.ProjectInfo) error {
+	scripts := append([]Script{}, formatScripts...)
+	if lint := g.lintScript(project); lint != "" {
+		scripts = append(scripts, Script{Name: "lint", Command: lint})
+	}
+
+	path := filepath.Join(project.Dir, "package.json")
+	for _, script := range scripts {
+		if project.HasScript(script.Name) {
+			continue
+		}
+		if err := utils.SetJSONPath(path, []string{"scripts", script.Name}, script.Command); err != nil {
+			return err
+		}
+		g.AddedScripts = append(g.AddedScripts, script.Name)
+	}
+	return nil
+}
+
+// lintScript returns the lint script added to TypeScript projects without one
+func (g *Generator) lintScript(project *projects.ProjectInfo) string {
+	if project.HasScript("lint") {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(project.Dir, "tsconfig.json")); err != nil {
+		return ""
+	}
+	return "tsc --noEmit"
+}
+
+// checkTaskFiles warns when an existing Makefile or Taskfile does not run golangci-lint
+func (g *Generator) checkTaskFiles(project *projects.ProjectInfo) {
+	for _, name := range []string{"Makefile", "Taskfile.yml"} {
+		content, err := os.ReadFile(filepath.Join(project.Dir, name))
+		if err != nil || strings.Contains(string(content), "golangci-lint") {
+			continue
+		}
+		g.Warnings = append(g.Warnings, fmt.Sprintf("add golangci-lint run to the lint target of %s, or regenerate it with: initiator tasks --force", filepath.Join(project.RelativePath(g.Dir), name)))
+	}
+}
+
+// render executes a configuration template
+func render(name string, content string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{"quote": quote}).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+// quote returns a double-quoted YAML string
+func quote(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
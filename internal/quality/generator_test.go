@@ -0,0 +1,136 @@
+package quality
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerate_GoProject(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte("lint:\n\tgo vet ./...\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator(dir, []*projects.ProjectInfo{project}, PreCommit, false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{".editorconfig", ".golangci.yml", ".pre-commit-config.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".pre-commit-config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Repos []struct {
+			Repo  string `yaml:"repo"`
+			Hooks []struct {
+				ID    string `yaml:"id"`
+				Entry string `yaml:"entry"`
+				Files string `yaml:"files"`
+			} `yaml:"hooks"`
+		} `yaml:"repos"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+	local := config.Repos[len(config.Repos)-1]
+	if local.Repo != "local" || len(local.Hooks) != 1 || local.Hooks[0].Entry != "golangci-lint run" || local.Hooks[0].Files != `\.go$` {
+		t.Errorf("unexpected local hooks: %+v", local)
+	}
+
+	if len(generator.Warnings) != 2 || !strings.Contains(generator.Warnings[0], "Makefile") {
+		t.Errorf("expected a warning about the Makefile lint target, got %v", generator.Warnings)
+	}
+
+	if err := generator.Generate(); err == nil {
+		t.Error("expected an error when the files already exist")
+	}
+}
+
+func TestGenerate_FullStackLefthook(t *testing.T) {
+	dir := t.TempDir()
+	api := filepath.Join(dir, "api")
+	web := filepath.Join(dir, "web")
+	for _, d := range []string{api, web} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkgJSON := "{\n  \"name\": \"web\",\n  \"scripts\": {\n    \"dev\": \"next dev\",\n    \"lint\": \"next lint\"\n  }\n}\n"
+	if err := os.WriteFile(filepath.Join(web, "package.json"), []byte(pkgJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	frontend, err := projects.DetectProject(web)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &projects.ProjectInfo{Dir: api, Name: "shop/api", Type: projects.GoLang, GoType: projects.WebGo}
+
+	generator := NewGenerator(dir, []*projects.ProjectInfo{backend, frontend}, Lefthook, false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, name := range []string{".editorconfig", "lefthook.yml", "api/.golangci.yml", "web/.prettierrc.json", "web/.prettierignore"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be generated", name)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "lefthook.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		PreCommit struct {
+			Commands map[string]struct {
+				Root string `yaml:"root"`
+				Run  string `yaml:"run"`
+			} `yaml:"commands"`
+		} `yaml:"pre-commit"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+	if command := config.PreCommit.Commands["golangci-lint-api"]; command.Root != "api/" || command.Run != "golangci-lint run" {
+		t.Errorf("unexpected golangci-lint command: %+v", command)
+	}
+	if command := config.PreCommit.Commands["lint-web"]; command.Root != "web/" || command.Run != "npm run lint" {
+		t.Errorf("unexpected lint command: %+v", command)
+	}
+
+	// The existing lint script is kept, the format scripts are added
+	if !reflect.DeepEqual(generator.AddedScripts, []string{"format", "format:check"}) {
+		t.Errorf("expected the format scripts to be added, got %v", generator.AddedScripts)
+	}
+	updated, err := os.ReadFile(filepath.Join(web, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(updated), "\"lint\": \"next lint\",\n    \"format\": \"prettier --write .\",\n    \"format:check\": \"prettier --check .\"\n  }") {
+		t.Errorf("unexpected package.json:\n%s", updated)
+	}
+}
+
+func TestParseHookManager(t *testing.T) {
+	if manager, err := ParseHookManager("Lefthook"); err != nil || manager != Lefthook {
+		t.Errorf("expected lefthook, got %q %v", manager, err)
+	}
+	if _, err := ParseHookManager("husky"); err == nil {
+		t.Error("expected an error for an unsupported hook manager")
+	}
+}
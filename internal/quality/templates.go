@@ -0,0 +1,112 @@
+package quality
+
+const editorconfigTemplate = `# EditorConfig, see https://editorconfig.org
+root = true
+
+[*]
+charset = utf-8
+end_of_line = lf
+insert_final_newline = true
+trim_trailing_whitespace = true
+indent_style = space
+indent_size = 2
+{{- if .Go}}
+
+[*.go]
+indent_style = tab
+indent_size = 4
+{{- end}}
+
+[{Makefile,*.mk}]
+indent_style = tab
+
+[*.md]
+trim_trailing_whitespace = false
+`
+
+const golangciTemplate = `# golangci-lint configuration, see https://golangci-lint.run/usage/configuration/
+version: "2"
+
+run:
+  timeout: 5m
+
+linters:
+  default: standard
+  enable:
+    - bodyclose
+    - errorlint
+    - gocritic
+    - gosec
+    - misspell
+    - revive
+    - unconvert
+    - unparam
+  exclusions:
+    presets:
+      - comments
+      - std-error-handling
+    rules:
+      - path: _test\.go
+        linters:
+          - gosec
+
+formatters:
+  enable:
+    - gofmt
+    - goimports
+`
+
+const prettierrcTemplate = `{
+  "printWidth": 100,
+  "trailingComma": "all"
+}
+`
+
+const prettierignoreTemplate = `dist/
+build/
+.next/
+coverage/
+package-lock.json
+pnpm-lock.yaml
+yarn.lock
+bun.lockb
+`
+
+const preCommitTemplate = `# pre-commit hooks, see https://pre-commit.com
+# Install them with: pre-commit install
+repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v5.0.0
+    hooks:
+      - id: trailing-whitespace
+      - id: end-of-file-fixer
+      - id: check-yaml
+        args: [--allow-multiple-documents]
+      - id: check-merge-conflict
+      - id: check-added-large-files
+  - repo: local
+    hooks:
+{{- range .Hooks}}
+      - id: {{.ID}}
+        name: {{.Name}}
+        entry: {{quote .Entry}}
+        language: system
+        files: {{quote .Files}}
+        pass_filenames: false
+{{- end}}
+`
+
+const lefthookTemplate = `# Lefthook hooks, see https://lefthook.dev
+# Install them with: lefthook install
+pre-commit:
+  parallel: true
+  commands:
+{{- range .Hooks}}
+    {{.ID}}:
+{{- if .Root}}
+      root: {{quote .Root}}
+{{- end}}
+      glob: {{quote .Glob}}
+      run: {{quote .Command}}
+{{- end}}
+`
@@ -0,0 +1,204 @@
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// FieldType is the type of a resource field.
+type FieldType string
+
+const (
+	StringField FieldType = "string"
+	IntField    FieldType = "int"
+	Int64Field  FieldType = "int64"
+	FloatField  FieldType = "float"
+	BoolField   FieldType = "bool"
+	TimeField   FieldType = "time"
+)
+
+// fieldTypeAliases maps the accepted spellings of a field type to the canonical type.
+var fieldTypeAliases = map[string]FieldType{
+	"string":   StringField,
+	"text":     StringField,
+	"int":      IntField,
+	"integer":  IntField,
+	"int64":    Int64Field,
+	"float":    FloatField,
+	"float64":  FloatField,
+	"number":   FloatField,
+	"bool":     BoolField,
+	"boolean":  BoolField,
+	"time":     TimeField,
+	"datetime": TimeField,
+}
+
+// fieldNamePattern matches valid field names such as name, first_name or firstName.
+var fieldNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// Field is a single attribute of a generated resource.
+type Field struct {
+	Name string
+	Type FieldType
+}
+
+// ParseFields parses a field list such as "name:string,age:int".
+// A field without a type defaults to string.
+//
+// Parameters:
+//   - spec: The comma separated list of name:type pairs
+//
+// Returns:
+//   - []Field: The parsed fields in the given order
+//   - error: An error if a name or type is invalid or a name is repeated
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, typeName, hasType := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if !hasType {
+			typeName = string(StringField)
+		}
+
+		if !fieldNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid field name %q: use letters, digits and underscores, starting with a letter", name)
+		}
+		if strings.EqualFold(name, "id") {
+			return nil, fmt.Errorf("field %q is reserved: every resource gets a generated id", name)
+		}
+
+		fieldType, ok := fieldTypeAliases[strings.ToLower(strings.TrimSpace(typeName))]
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %q for field %q: use string, int, int64, float, bool or time", typeName, name)
+		}
+
+		key := utils.SnakeCase(name)
+		if seen[key] {
+			return nil, fmt.Errorf("field %q is defined more than once", name)
+		}
+		seen[key] = true
+
+		fields = append(fields, Field{Name: name, Type: fieldType})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required, e.g. --fields name:string,age:int")
+	}
+
+	return fields, nil
+}
+
+// GoType returns the Go type of the field.
+func (f Field) GoType() string {
+	switch f.Type {
+	case IntField:
+		return "int"
+	case Int64Field:
+		return "int64"
+	case FloatField:
+		return "float64"
+	case BoolField:
+		return "bool"
+	case TimeField:
+		return "time.Time"
+	}
+	return "string"
+}
+
+// TSType returns the TypeScript type of the field.
+func (f Field) TSType() string {
+	switch f.Type {
+	case IntField, Int64Field, FloatField:
+		return "number"
+	case BoolField:
+		return "boolean"
+	}
+	return "string"
+}
+
+// SampleJSON returns a JSON literal that is a valid value for the field,
+// used in the generated tests.
+func (f Field) SampleJSON() string {
+	switch f.Type {
+	case IntField, Int64Field:
+		return "1"
+	case FloatField:
+		return "1.5"
+	case BoolField:
+		return "true"
+	case TimeField:
+		return `"2024-01-01T00:00:00Z"`
+	}
+	return `"example"`
+}
+
+// TSInvalid returns a TypeScript expression that is true when value is not a
+// valid value for the field.
+func (f Field) TSInvalid(value string) string {
+	switch f.Type {
+	case IntField, Int64Field:
+		return "!Number.isInteger(" + value + ")"
+	case FloatField:
+		return "typeof " + value + " !== 'number'"
+	case BoolField:
+		return "typeof " + value + " !== 'boolean'"
+	case TimeField:
+		return "typeof " + value + " !== 'string' || Number.isNaN(Date.parse(" + value + "))"
+	}
+	return "typeof " + value + " !== 'string'"
+}
+
+// Requirement describes the expected value in validation error messages.
+func (f Field) Requirement() string {
+	switch f.Type {
+	case IntField, Int64Field:
+		return "an integer"
+	case FloatField:
+		return "a number"
+	case BoolField:
+		return "a boolean"
+	case TimeField:
+		return "an ISO 8601 date string"
+	}
+	return "a string"
+}
+
+// JSONSchema returns the JSON schema of the field as a TypeScript object literal.
+func (f Field) JSONSchema() string {
+	switch f.Type {
+	case IntField, Int64Field:
+		return "{ type: 'integer' }"
+	case FloatField:
+		return "{ type: 'number' }"
+	case BoolField:
+		return "{ type: 'boolean' }"
+	case TimeField:
+		return "{ type: 'string', format: 'date-time' }"
+	}
+	return "{ type: 'string' }"
+}
+
+// Validator returns the class-validator decorator used for the field in NestJS DTOs.
+func (f Field) Validator() string {
+	switch f.Type {
+	case IntField, Int64Field:
+		return "IsInt"
+	case FloatField:
+		return "IsNumber"
+	case BoolField:
+		return "IsBoolean"
+	case TimeField:
+		return "IsDateString"
+	}
+	return "IsString"
+}
@@ -0,0 +1,352 @@
+package resource
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/utils"
+)
+
+// Generator generates a CRUD resource inside an existing project.
+type Generator struct {
+	Name    string
+	Fields  []Field
+	Project *projects.ProjectInfo
+	// Warnings collects manual steps that could not be applied automatically.
+	Warnings []string
+}
+
+// NewGenerator creates a new Generator
+func NewGenerator(name string, fields []Field, project *projects.ProjectInfo) *Generator {
+	return &Generator{
+		Name:    name,
+		Fields:  fields,
+		Project: project,
+	}
+}
+
+// templateData is the data passed to the resource templates.
+type templateData struct {
+	Module string
+	// Type is the exported type name, e.g. BlogPost
+	Type string
+	// Var is the local variable name, e.g. blogPost
+	Var string
+	// Label is the human readable name, e.g. blog post
+	Label string
+	// File is the base file name, e.g. blog_post
+	File string
+	// Kebab is the base name of TypeScript files, e.g. blog-post
+	Kebab string
+	// Path is the collection route, e.g. /blog-posts
+	Path string
+	// PluralType, PluralVar and PluralKebab name NestJS classes, members and files,
+	// e.g. BlogPosts, blogPosts and blog-posts
+	PluralType  string
+	PluralVar   string
+	PluralKebab string
+	Fields      []fieldData
+	UsesTime    bool
+	// SampleJSON is a valid request body used by the generated tests
+	SampleJSON string
+	// Validators lists the class-validator decorators used by the NestJS DTO
+	Validators string
+}
+
+// generatedFile is a file written by the generator and the template it is rendered from.
+type generatedFile struct {
+	path     string
+	template string
+}
+
+// fieldData is a field prepared for the templates.
+type fieldData struct {
+	Field
+	GoName string
+	JSON   string
+}
+
+// Generate writes the resource files into the project and registers the resource.
+// Go web projects use Echo idioms, Node.js projects follow the conventions of
+// NestJS, Express or Fastify depending on the detected framework.
+func (g *Generator) Generate() error {
+	files, err := g.files()
+	if err != nil {
+		return err
+	}
+	if err := g.checkNotExists(files); err != nil {
+		return err
+	}
+	data := g.buildData()
+	if g.Project.Type == projects.GoLang {
+		if err := g.checkNotDeclared("internal/models", data.Type); err != nil {
+			return err
+		}
+	}
+
+	// Render every file before writing any, so a failure leaves the project untouched
+	rendered := make([][]byte, len(files))
+	for i, file := range files {
+		if rendered[i], err = utils.RenderTemplate(file.path, file.template, nil, data); err != nil {
+			return err
+		}
+	}
+	for i, file := range files {
+		if err := utils.WriteFile(g.Project.Dir, file.path, rendered[i]); err != nil {
+			return err
+		}
+	}
+
+	return g.register(data)
+}
+
+// describeProject returns a human readable project kind for error messages.
+func (g *Generator) describeProject() string {
+	if g.Project.Type == projects.GoLang {
+		return fmt.Sprintf("Go %s project", g.Project.GoType)
+	}
+	return fmt.Sprintf("%s project", g.Project.NodeType)
+}
+
+// Files returns the paths, relative to the project directory, that Generate writes.
+func (g *Generator) Files() []string {
+	files, _ := g.files()
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.path
+	}
+	return paths
+}
+
+// files returns the files of the resource for the detected project type.
+func (g *Generator) files() ([]generatedFile, error) {
+	data := g.buildData()
+
+	switch {
+	case g.Project.Type == projects.GoLang && g.Project.GoType == projects.WebGo:
+		return []generatedFile{
+			{"internal/models/" + data.File + ".go", goModelTemplate},
+			{"internal/services/" + data.File + "_service.go", goServiceTemplate},
+			{"internal/handlers/" + data.File + "_handler.go", goHandlerTemplate},
+			{"internal/handlers/" + data.File + "_handler_test.go", goHandlerTestTemplate},
+			{"internal/routes/" + data.File + ".go", goRoutesTemplate},
+		}, nil
+	case g.Project.Type == projects.NodeJS && g.Project.NodeType == projects.Express:
+		return []generatedFile{
+			{"src/models/" + data.Kebab + ".ts", tsModelTemplate},
+			{"src/schemas/" + data.Kebab + ".schema.ts", expressSchemaTemplate},
+			{"src/services/" + data.Kebab + ".service.ts", tsServiceTemplate},
+			{"src/routes/" + data.Kebab + ".routes.ts", expressRoutesTemplate},
+			{"src/routes/" + data.Kebab + ".routes.test.ts", expressTestTemplate},
+		}, nil
+	case g.Project.Type == projects.NodeJS && g.Project.NodeType == projects.Fastify:
+		return []generatedFile{
+			{"src/models/" + data.Kebab + ".ts", tsModelTemplate},
+			{"src/schemas/" + data.Kebab + ".schema.ts", fastifySchemaTemplate},
+			{"src/services/" + data.Kebab + ".service.ts", tsServiceTemplate},
+			{"src/routes/" + data.Kebab + ".routes.ts", fastifyRoutesTemplate},
+			{"src/routes/" + data.Kebab + ".routes.test.ts", fastifyTestTemplate},
+		}, nil
+	case g.Project.Type == projects.NodeJS && g.Project.NodeType == projects.NestJS:
+		dir := "src/" + data.PluralKebab + "/"
+		return []generatedFile{
+			{dir + "entities/" + data.Kebab + ".entity.ts", nestEntityTemplate},
+			{dir + "dto/create-" + data.Kebab + ".dto.ts", nestCreateDtoTemplate},
+			{dir + "dto/update-" + data.Kebab + ".dto.ts", nestUpdateDtoTemplate},
+			{dir + data.PluralKebab + ".service.ts", nestServiceTemplate},
+			{dir + data.PluralKebab + ".service.spec.ts", nestServiceSpecTemplate},
+			{dir + data.PluralKebab + ".controller.ts", nestControllerTemplate},
+			{dir + data.PluralKebab + ".module.ts", nestModuleTemplate},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("resource generation supports Go web (Echo), NestJS, Express and Fastify projects, found %s", g.describeProject())
+}
+
+// register wires the generated resource into the application.
+func (g *Generator) register(data templateData) error {
+	var manual string
+	var err error
+
+	switch g.Project.NodeType {
+	case projects.Express:
+		manual, err = projects.RegisterNodeRoute(g.Project.Dir,
+			fmt.Sprintf("import { %sRouter } from './routes/%s.routes';", data.Var, data.Kebab),
+			fmt.Sprintf("app.use('%s', %sRouter);", data.Path, data.Var))
+		g.Warnings = append(g.Warnings, nodeTestStep(data))
+	case projects.Fastify:
+		manual, err = projects.RegisterNodeRoute(g.Project.Dir,
+			fmt.Sprintf("import { %sRoutes } from './routes/%s.routes';", data.Var, data.Kebab),
+			fmt.Sprintf("app.register(%sRoutes, { prefix: '%s' });", data.Var, data.Path))
+		g.Warnings = append(g.Warnings, nodeTestStep(data))
+	case projects.NestJS:
+		err = g.registerNestModule(data)
+	default:
+		manual, err = projects.RegisterGoRoute(g.Project.Dir, fmt.Sprintf("register%sRoutes(e)", data.Type))
+	}
+
+	if err != nil {
+		return err
+	}
+	if manual != "" {
+		g.Warnings = append(g.Warnings, manual)
+	}
+	return nil
+}
+
+// nodeTestStep explains how to run the test generated for Express and Fastify resources,
+// which uses the built-in Node.js test runner.
+func nodeTestStep(data templateData) string {
+	return fmt.Sprintf("run the generated test with `node --require ts-node/register --test src/routes/%s.routes.test.ts`", data.Kebab)
+}
+
+// registerNestModule adds the generated module to the imports of AppModule and
+// enables the global ValidationPipe so the DTO decorators are enforced.
+func (g *Generator) registerNestModule(data templateData) error {
+	module := data.PluralType + "Module"
+	importLine := fmt.Sprintf("import { %s } from './%s/%s.module';", module, data.PluralKebab, data.PluralKebab)
+
+	appModulePath := filepath.Join(g.Project.Dir, "src", "app.module.ts")
+	content, err := os.ReadFile(appModulePath)
+	if err != nil {
+		g.Warnings = append(g.Warnings, fmt.Sprintf("add %s to the imports of your root module", module))
+		return nil
+	}
+
+	source := string(content)
+	anchor := "imports: ["
+	index := strings.Index(source, anchor)
+	switch {
+	case strings.Contains(source, module):
+	case index < 0:
+		g.Warnings = append(g.Warnings, fmt.Sprintf("add %s to the imports of AppModule in src/app.module.ts", module))
+	default:
+		insertAt := index + len(anchor)
+		entry := module + ", "
+		if strings.HasPrefix(strings.TrimSpace(source[insertAt:]), "]") {
+			entry = module
+		}
+		source = source[:insertAt] + entry + source[insertAt:]
+		if err := os.WriteFile(appModulePath, []byte(source), 0644); err != nil {
+			return fmt.Errorf("failed to update app.module.ts: %v", err)
+		}
+		if err := utils.InsertAfterLastLine(appModulePath, "import ", importLine); err != nil {
+			return err
+		}
+	}
+
+	mainPath := filepath.Join(g.Project.Dir, "src", "main.ts")
+	found := false
+	if _, err := os.Stat(mainPath); err == nil {
+		found, err = utils.InsertBeforeLine(mainPath, "await app.listen(", "app.useGlobalPipes(new ValidationPipe({ whitelist: true }));")
+		if err != nil {
+			return err
+		}
+		if found {
+			if err := utils.InsertAfterLastLine(mainPath, "import ", "import { ValidationPipe } from '@nestjs/common';"); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		g.Warnings = append(g.Warnings, "enable validation with `app.useGlobalPipes(new ValidationPipe({ whitelist: true }))` in src/main.ts")
+	}
+
+	if !g.Project.HasDependency("class-validator") || !g.Project.HasDependency("class-transformer") {
+		pm := g.Project.PackageManager.OrDefault()
+		install := strings.Join(pm.Add(false, "class-validator", "class-transformer").Args, " ")
+		g.Warnings = append(g.Warnings, fmt.Sprintf("install the DTO validation packages with `%s`", install))
+	}
+	return nil
+}
+
+// buildData prepares the template data for the resource.
+func (g *Generator) buildData() templateData {
+	data := templateData{
+		Module: g.Project.Name,
+		Type:   utils.PascalCase(g.Name),
+		Var:    utils.CamelCase(g.Name),
+		Label:  strings.ReplaceAll(utils.KebabCase(g.Name), "-", " "),
+		File:   utils.SnakeCase(g.Name),
+		Kebab:  utils.KebabCase(g.Name),
+		Path:   "/" + utils.Plural(utils.KebabCase(g.Name)),
+
+		PluralType:  utils.Plural(utils.PascalCase(g.Name)),
+		PluralVar:   utils.Plural(utils.CamelCase(g.Name)),
+		PluralKebab: utils.Plural(utils.KebabCase(g.Name)),
+	}
+
+	for _, f := range g.Fields {
+		data.Fields = append(data.Fields, fieldData{
+			Field:  f,
+			GoName: utils.GoFieldName(f.Name),
+			JSON:   f.Name,
+		})
+		if f.Type == TimeField {
+			data.UsesTime = true
+		}
+	}
+
+	var sample, validators []string
+	seen := map[string]bool{}
+	for _, f := range g.Fields {
+		sample = append(sample, fmt.Sprintf("%q:%s", f.Name, f.SampleJSON()))
+		if !seen[f.Validator()] {
+			seen[f.Validator()] = true
+			validators = append(validators, f.Validator())
+		}
+	}
+	sort.Strings(validators)
+	data.SampleJSON = "{" + strings.Join(sample, ",") + "}"
+	data.Validators = strings.Join(validators, ", ")
+
+	return data
+}
+
+// checkNotExists makes sure no generated file overwrites existing code.
+func (g *Generator) checkNotExists(files []generatedFile) error {
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(g.Project.Dir, file.path)); err == nil {
+			return fmt.Errorf("%s already exists, refusing to overwrite it", file.path)
+		}
+	}
+	return nil
+}
+
+// checkNotDeclared makes sure the Go package at pkgDir, relative to the project
+// directory, does not already declare the type, like the models written by
+// add openapi, which would not compile once the resource declares it again.
+func (g *Generator) checkNotDeclared(pkgDir, typeName string) error {
+	paths, err := filepath.Glob(filepath.Join(g.Project.Dir, pkgDir, "*.go"))
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %v", pkgDir, err)
+	}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %v", filepath.Join(pkgDir, filepath.Base(path)), err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if spec.(*ast.TypeSpec).Name.Name == typeName {
+					return fmt.Errorf("type %s is already declared in %s, pick another resource name", typeName, filepath.Join(pkgDir, filepath.Base(path)))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"github.com/moabdelazem/initiator/internal/testutil"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("name:string, age:integer,active:bool,nickname")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []Field{
+		{"name", StringField},
+		{"age", IntField},
+		{"active", BoolField},
+		{"nickname", StringField},
+	}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d", len(expected), len(fields))
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("expected field %d to be %+v, got %+v", i, expected[i], fields[i])
+		}
+	}
+}
+
+func TestParseFields_Invalid(t *testing.T) {
+	specs := []string{
+		"",
+		"name:uuid",
+		"1name:string",
+		"id:int",
+		"name:string,name:int",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseFields(spec); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
+func TestGenerate_Go(t *testing.T) {
+	dir := t.TempDir()
+	routesDir := filepath.Join(dir, "internal", "routes")
+	if err := os.MkdirAll(routesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	routes := "package routes\n\nfunc Register(e *echo.Echo) {\n\t" + projects.RoutesMarker + "\n}\n"
+	if err := os.WriteFile(filepath.Join(routesDir, "routes.go"), []byte(routes), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := []Field{{"title", StringField}, {"publishedAt", TimeField}}
+	project := &projects.ProjectInfo{Dir: dir, Name: "blog", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator("blog-post", fields, project)
+
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "models", "blog_post.go"),
+		"type BlogPost struct", "PublishedAt time.Time `json:\"publishedAt\"`")
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "services", "blog_post_service.go"),
+		"type BlogPostService interface", "func NewInMemoryBlogPostService()")
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "handlers", "blog_post_handler_test.go"),
+		`{"title":"example","publishedAt":"2024-01-01T00:00:00Z"}`)
+	testutil.ExpectContains(t, filepath.Join(dir, "internal", "routes", "blog_post.go"), `e.Group("/blog-posts")`)
+	testutil.ExpectContains(t, filepath.Join(routesDir, "routes.go"), "\tregisterBlogPostRoutes(e)\n")
+
+	// Generating the same resource twice must not overwrite the first one
+	if err := generator.Generate(); err == nil {
+		t.Fatal("expected an error when the resource already exists")
+	}
+}
+
+func TestGenerate_GoDeclaredType(t *testing.T) {
+	dir := t.TempDir()
+	modelsDir := filepath.Join(dir, "internal", "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	models := "package models\n\ntype (\n\tPet struct {\n\t\tName string\n\t}\n\tPets []Pet\n)\n"
+	if err := os.WriteFile(filepath.Join(modelsDir, "openapi.go"), []byte(models), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The models written by add openapi already declare Pet
+	project := &projects.ProjectInfo{Dir: dir, Name: "petstore", Type: projects.GoLang, GoType: projects.WebGo}
+	err := NewGenerator("pet", nil, project).Generate()
+	if err == nil || !strings.Contains(err.Error(), "internal/models/openapi.go") {
+		t.Fatalf("expected an error naming the file declaring Pet, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(modelsDir, "pet.go")); !os.IsNotExist(err) {
+		t.Error("expected no file to be written")
+	}
+}
+
+func TestGenerate_GoKeyword(t *testing.T) {
+	dir := t.TempDir()
+
+	// The variable named type does not compile, and nothing may be written before that is known
+	project := &projects.ProjectInfo{Dir: dir, Name: "shop", Type: projects.GoLang, GoType: projects.WebGo}
+	if err := NewGenerator("type", nil, project).Generate(); err == nil {
+		t.Fatal("expected an error for a resource named after a Go keyword")
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("expected no file to be written, got %v, %v", entries, err)
+	}
+}
+
+func TestGenerate_Express(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	index := "import express from 'express';\n\nconst app = express();\n\napp.listen(3000);\n"
+	if err := os.WriteFile(filepath.Join(dir, "src", "index.ts"), []byte(index), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "shop", Type: projects.NodeJS, NodeType: projects.Express}
+	if err := NewGenerator("category", []Field{{"name", StringField}}, project).Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "schemas", "category.schema.ts"), "errors.push('name must be a string');")
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "routes", "category.routes.test.ts"), `body: '{"name":"example"}'`)
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "index.ts"),
+		"import { categoryRouter } from './routes/category.routes';",
+		"app.use('/categories', categoryRouter);\n\napp.listen(3000);")
+}
+
+func TestGenerate_NestJS(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	appModule := "import { Module } from '@nestjs/common';\n\n@Module({\n  imports: [],\n})\nexport class AppModule {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "src", "app.module.ts"), []byte(appModule), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "shop", Type: projects.NodeJS, NodeType: projects.NestJS}
+	generator := NewGenerator("order-item", []Field{{"quantity", IntField}}, project)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "order-items", "dto", "create-order-item.dto.ts"),
+		"import { IsInt } from 'class-validator';", "@IsInt()\n  quantity: number;")
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "order-items", "order-items.controller.ts"),
+		"@Controller('order-items')", "export class OrderItemsController")
+	testutil.ExpectContains(t, filepath.Join(dir, "src", "app.module.ts"),
+		"import { OrderItemsModule } from './order-items/order-items.module';", "imports: [OrderItemsModule],")
+
+	// Without src/main.ts and class-validator the user has to finish the setup by hand
+	if len(generator.Warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", generator.Warnings)
+	}
+}
@@ -0,0 +1,306 @@
+package resource
+
+const goModelTemplate = `package models
+{{if .UsesTime}}
+import "time"
+{{end}}
+// {{.Type}} represents a {{.Label}}.
+type {{.Type}} struct {
+	ID int64 ` + "`" + `json:"id"` + "`" + `
+{{- range .Fields}}
+	{{.GoName}} {{.GoType}} ` + "`" + `json:"{{.JSON}}"` + "`" + `
+{{- end}}
+}
+`
+
+const goServiceTemplate = `package services
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"{{.Module}}/internal/models"
+)
+
+// Err{{.Type}}NotFound is returned when a {{.Label}} does not exist.
+var Err{{.Type}}NotFound = errors.New("{{.Label}} not found")
+
+// {{.Type}}Service defines the operations available on {{.Label}} resources.
+type {{.Type}}Service interface {
+	List() []models.{{.Type}}
+	Get(id int64) (models.{{.Type}}, error)
+	Create({{.Var}} models.{{.Type}}) models.{{.Type}}
+	Update(id int64, {{.Var}} models.{{.Type}}) (models.{{.Type}}, error)
+	Delete(id int64) error
+}
+
+// InMemory{{.Type}}Service is a {{.Type}}Service that keeps {{.Label}} resources in memory.
+// It is safe for concurrent use.
+type InMemory{{.Type}}Service struct {
+	mu     sync.RWMutex
+	items  map[int64]models.{{.Type}}
+	nextID int64
+}
+
+// NewInMemory{{.Type}}Service creates an empty InMemory{{.Type}}Service.
+func NewInMemory{{.Type}}Service() *InMemory{{.Type}}Service {
+	return &InMemory{{.Type}}Service{
+		items:  make(map[int64]models.{{.Type}}),
+		nextID: 1,
+	}
+}
+
+// List returns every {{.Label}} ordered by id.
+func (s *InMemory{{.Type}}Service) List() []models.{{.Type}} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make([]models.{{.Type}}, 0, len(s.items))
+	for _, item := range s.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items
+}
+
+// Get returns the {{.Label}} with the given id.
+func (s *InMemory{{.Type}}Service) Get(id int64) (models.{{.Type}}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[id]
+	if !ok {
+		return models.{{.Type}}{}, Err{{.Type}}NotFound
+	}
+	return item, nil
+}
+
+// Create stores a new {{.Label}} and assigns it an id.
+func (s *InMemory{{.Type}}Service) Create({{.Var}} models.{{.Type}}) models.{{.Type}} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	{{.Var}}.ID = s.nextID
+	s.nextID++
+	s.items[{{.Var}}.ID] = {{.Var}}
+	return {{.Var}}
+}
+
+// Update replaces the {{.Label}} with the given id.
+func (s *InMemory{{.Type}}Service) Update(id int64, {{.Var}} models.{{.Type}}) (models.{{.Type}}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return models.{{.Type}}{}, Err{{.Type}}NotFound
+	}
+	{{.Var}}.ID = id
+	s.items[id] = {{.Var}}
+	return {{.Var}}, nil
+}
+
+// Delete removes the {{.Label}} with the given id.
+func (s *InMemory{{.Type}}Service) Delete(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[id]; !ok {
+		return Err{{.Type}}NotFound
+	}
+	delete(s.items, id)
+	return nil
+}
+`
+
+const goHandlerTemplate = `package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"{{.Module}}/internal/models"
+	"{{.Module}}/internal/services"
+)
+
+// {{.Type}}Handler serves the CRUD endpoints of the {{.Label}} resource.
+type {{.Type}}Handler struct {
+	service services.{{.Type}}Service
+}
+
+// New{{.Type}}Handler creates a {{.Type}}Handler backed by the given service.
+func New{{.Type}}Handler(service services.{{.Type}}Service) *{{.Type}}Handler {
+	return &{{.Type}}Handler{service: service}
+}
+
+// List handles GET {{.Path}}.
+func (h *{{.Type}}Handler) List(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.service.List())
+}
+
+// Get handles GET {{.Path}}/:id.
+func (h *{{.Type}}Handler) Get(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return err
+	}
+
+	{{.Var}}, err := h.service.Get(id)
+	if err != nil {
+		return h.httpError(err)
+	}
+	return c.JSON(http.StatusOK, {{.Var}})
+}
+
+// Create handles POST {{.Path}}.
+func (h *{{.Type}}Handler) Create(c echo.Context) error {
+	var {{.Var}} models.{{.Type}}
+	if err := c.Bind(&{{.Var}}); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, h.service.Create({{.Var}}))
+}
+
+// Update handles PUT {{.Path}}/:id.
+func (h *{{.Type}}Handler) Update(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return err
+	}
+
+	var {{.Var}} models.{{.Type}}
+	if err := c.Bind(&{{.Var}}); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	updated, err := h.service.Update(id, {{.Var}})
+	if err != nil {
+		return h.httpError(err)
+	}
+	return c.JSON(http.StatusOK, updated)
+}
+
+// Delete handles DELETE {{.Path}}/:id.
+func (h *{{.Type}}Handler) Delete(c echo.Context) error {
+	id, err := h.id(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(id); err != nil {
+		return h.httpError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// id parses the id path parameter.
+func (h *{{.Type}}Handler) id(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return id, nil
+}
+
+// httpError maps service errors to HTTP errors.
+func (h *{{.Type}}Handler) httpError(err error) error {
+	if errors.Is(err, services.Err{{.Type}}NotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return err
+}
+`
+
+const goHandlerTestTemplate = `package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"{{.Module}}/internal/models"
+	"{{.Module}}/internal/services"
+)
+
+func Test{{.Type}}Handler_CreateAndGet(t *testing.T) {
+	e := echo.New()
+	h := New{{.Type}}Handler(services.NewInMemory{{.Type}}Service())
+
+	body := ` + "`" + `{ {{- range $i, $f := .Fields}}{{if $i}},{{end}}"{{$f.JSON}}":{{$f.SampleJSON}}{{end -}} }` + "`" + `
+	req := httptest.NewRequest(http.MethodPost, "{{.Path}}", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	if err := h.Create(e.NewContext(req, rec)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+
+	var created models.{{.Type}}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "{{.Path}}/"+strconv.FormatInt(created.ID, 10), nil)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.FormatInt(created.ID, 10))
+
+	if err := h.Get(c); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func Test{{.Type}}Handler_GetNotFound(t *testing.T) {
+	e := echo.New()
+	h := New{{.Type}}Handler(services.NewInMemory{{.Type}}Service())
+
+	req := httptest.NewRequest(http.MethodGet, "{{.Path}}/42", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("42")
+
+	err := h.Get(c)
+	httpErr, ok := err.(*echo.HTTPError)
+	if !ok || httpErr.Code != http.StatusNotFound {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
+`
+
+const goRoutesTemplate = `package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"{{.Module}}/internal/handlers"
+	"{{.Module}}/internal/services"
+)
+
+// register{{.Type}}Routes registers the CRUD routes of the {{.Label}} resource.
+func register{{.Type}}Routes(e *echo.Echo) {
+	h := handlers.New{{.Type}}Handler(services.NewInMemory{{.Type}}Service())
+
+	g := e.Group("{{.Path}}")
+	g.GET("", h.List)
+	g.POST("", h.Create)
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+}
+`
@@ -0,0 +1,422 @@
+package resource
+
+const tsModelTemplate = `export interface {{.Type}} {
+  id: number;
+{{- range .Fields}}
+  {{.JSON}}: {{.TSType}};
+{{- end}}
+}
+
+export type {{.Type}}Input = Omit<{{.Type}}, 'id'>;
+`
+
+const tsServiceTemplate = `import type { {{.Type}}, {{.Type}}Input } from '../models/{{.Kebab}}';
+
+// {{.Type}}Service keeps {{.Label}} resources in memory.
+export class {{.Type}}Service {
+  private readonly items = new Map<number, {{.Type}}>();
+  private nextId = 1;
+
+  list(): {{.Type}}[] {
+    return Array.from(this.items.values()).sort((a, b) => a.id - b.id);
+  }
+
+  get(id: number): {{.Type}} | undefined {
+    return this.items.get(id);
+  }
+
+  create(input: {{.Type}}Input): {{.Type}} {
+    const {{.Var}}: {{.Type}} = { ...input, id: this.nextId++ };
+    this.items.set({{.Var}}.id, {{.Var}});
+    return {{.Var}};
+  }
+
+  update(id: number, input: {{.Type}}Input): {{.Type}} | undefined {
+    if (!this.items.has(id)) {
+      return undefined;
+    }
+    const {{.Var}}: {{.Type}} = { ...input, id };
+    this.items.set(id, {{.Var}});
+    return {{.Var}};
+  }
+
+  delete(id: number): boolean {
+    return this.items.delete(id);
+  }
+}
+`
+
+const expressSchemaTemplate = `import type { {{.Type}}Input } from '../models/{{.Kebab}}';
+
+export interface ValidationResult<T> {
+  value?: T;
+  errors: string[];
+}
+
+// validate{{.Type}} checks that a request body is a valid {{.Label}}.
+export function validate{{.Type}}(input: unknown): ValidationResult<{{.Type}}Input> {
+  if (typeof input !== 'object' || input === null) {
+    return { errors: ['body must be a JSON object'] };
+  }
+
+  const body = input as Record<string, unknown>;
+  const errors: string[] = [];
+{{- range .Fields}}
+  if ({{.TSInvalid (printf "body['%s']" .JSON)}}) {
+    errors.push('{{.JSON}} must be {{.Requirement}}');
+  }
+{{- end}}
+
+  if (errors.length > 0) {
+    return { errors };
+  }
+
+  return {
+    value: {
+{{- range .Fields}}
+      {{.JSON}}: body['{{.JSON}}'] as {{.TSType}},
+{{- end}}
+    },
+    errors,
+  };
+}
+`
+
+const expressRoutesTemplate = `import express, { Router } from 'express';
+import { validate{{.Type}} } from '../schemas/{{.Kebab}}.schema';
+import { {{.Type}}Service } from '../services/{{.Kebab}}.service';
+
+// create{{.Type}}Router returns a router serving the CRUD endpoints of the {{.Label}} resource.
+export function create{{.Type}}Router(service = new {{.Type}}Service()): Router {
+  const router = Router();
+  router.use(express.json());
+
+  router.get('/', (_req, res) => {
+    res.json(service.list());
+  });
+
+  router.get('/:id', (req, res) => {
+    const {{.Var}} = service.get(Number(req.params.id));
+    if (!{{.Var}}) {
+      res.status(404).json({ message: '{{.Label}} not found' });
+      return;
+    }
+    res.json({{.Var}});
+  });
+
+  router.post('/', (req, res) => {
+    const { value, errors } = validate{{.Type}}(req.body);
+    if (!value) {
+      res.status(400).json({ errors });
+      return;
+    }
+    res.status(201).json(service.create(value));
+  });
+
+  router.put('/:id', (req, res) => {
+    const { value, errors } = validate{{.Type}}(req.body);
+    if (!value) {
+      res.status(400).json({ errors });
+      return;
+    }
+    const {{.Var}} = service.update(Number(req.params.id), value);
+    if (!{{.Var}}) {
+      res.status(404).json({ message: '{{.Label}} not found' });
+      return;
+    }
+    res.json({{.Var}});
+  });
+
+  router.delete('/:id', (req, res) => {
+    if (!service.delete(Number(req.params.id))) {
+      res.status(404).json({ message: '{{.Label}} not found' });
+      return;
+    }
+    res.status(204).end();
+  });
+
+  return router;
+}
+
+export const {{.Var}}Router = create{{.Type}}Router();
+`
+
+const expressTestTemplate = `import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import type { AddressInfo } from 'node:net';
+import express from 'express';
+import { create{{.Type}}Router } from './{{.Kebab}}.routes';
+
+test('creates and fetches a {{.Label}}', async () => {
+  const app = express();
+  app.use('{{.Path}}', create{{.Type}}Router());
+
+  const server = app.listen(0);
+  const { port } = server.address() as AddressInfo;
+  const baseUrl = 'http://127.0.0.1:' + port + '{{.Path}}';
+
+  try {
+    const created = await fetch(baseUrl, {
+      method: 'POST',
+      headers: { 'content-type': 'application/json' },
+      body: '{{.SampleJSON}}',
+    });
+    assert.equal(created.status, 201);
+
+    const { id } = (await created.json()) as { id: number };
+    const fetched = await fetch(baseUrl + '/' + id);
+    assert.equal(fetched.status, 200);
+
+    const invalid = await fetch(baseUrl, {
+      method: 'POST',
+      headers: { 'content-type': 'application/json' },
+      body: '{}',
+    });
+    assert.equal(invalid.status, 400);
+  } finally {
+    server.close();
+  }
+});
+`
+
+const fastifySchemaTemplate = `// JSON schemas validating {{.Label}} requests.
+export const {{.Var}}BodySchema = {
+  type: 'object',
+  required: [{{range $i, $f := .Fields}}{{if $i}}, {{end}}'{{$f.JSON}}'{{end}}],
+  additionalProperties: false,
+  properties: {
+{{- range .Fields}}
+    {{.JSON}}: {{.JSONSchema}},
+{{- end}}
+  },
+} as const;
+
+export const {{.Var}}ParamsSchema = {
+  type: 'object',
+  required: ['id'],
+  properties: {
+    id: { type: 'integer' },
+  },
+} as const;
+`
+
+const fastifyRoutesTemplate = `import type { FastifyPluginAsync } from 'fastify';
+import type { {{.Type}}Input } from '../models/{{.Kebab}}';
+import { {{.Var}}BodySchema, {{.Var}}ParamsSchema } from '../schemas/{{.Kebab}}.schema';
+import { {{.Type}}Service } from '../services/{{.Kebab}}.service';
+
+export interface {{.Type}}RoutesOptions {
+  service?: {{.Type}}Service;
+}
+
+// {{.Var}}Routes serves the CRUD endpoints of the {{.Label}} resource.
+export const {{.Var}}Routes: FastifyPluginAsync<{{.Type}}RoutesOptions> = async (app, options) => {
+  const service = options.service ?? new {{.Type}}Service();
+
+  app.get('/', async () => service.list());
+
+  app.get<{ Params: { id: number } }>(
+    '/:id',
+    { schema: { params: {{.Var}}ParamsSchema } },
+    async (request, reply) => {
+      const {{.Var}} = service.get(request.params.id);
+      if (!{{.Var}}) {
+        return reply.code(404).send({ message: '{{.Label}} not found' });
+      }
+      return {{.Var}};
+    },
+  );
+
+  app.post<{ Body: {{.Type}}Input }>(
+    '/',
+    { schema: { body: {{.Var}}BodySchema } },
+    async (request, reply) => reply.code(201).send(service.create(request.body)),
+  );
+
+  app.put<{ Params: { id: number }; Body: {{.Type}}Input }>(
+    '/:id',
+    { schema: { params: {{.Var}}ParamsSchema, body: {{.Var}}BodySchema } },
+    async (request, reply) => {
+      const {{.Var}} = service.update(request.params.id, request.body);
+      if (!{{.Var}}) {
+        return reply.code(404).send({ message: '{{.Label}} not found' });
+      }
+      return {{.Var}};
+    },
+  );
+
+  app.delete<{ Params: { id: number } }>(
+    '/:id',
+    { schema: { params: {{.Var}}ParamsSchema } },
+    async (request, reply) => {
+      if (!service.delete(request.params.id)) {
+        return reply.code(404).send({ message: '{{.Label}} not found' });
+      }
+      return reply.code(204).send();
+    },
+  );
+};
+`
+
+const fastifyTestTemplate = `import { test } from 'node:test';
+import assert from 'node:assert/strict';
+import Fastify from 'fastify';
+import { {{.Var}}Routes } from './{{.Kebab}}.routes';
+
+test('creates and fetches a {{.Label}}', async () => {
+  const app = Fastify();
+  await app.register({{.Var}}Routes, { prefix: '{{.Path}}' });
+
+  const created = await app.inject({
+    method: 'POST',
+    url: '{{.Path}}',
+    payload: {{.SampleJSON}},
+  });
+  assert.equal(created.statusCode, 201);
+
+  const { id } = created.json<{ id: number }>();
+  const fetched = await app.inject({ method: 'GET', url: '{{.Path}}/' + id });
+  assert.equal(fetched.statusCode, 200);
+
+  const invalid = await app.inject({ method: 'POST', url: '{{.Path}}', payload: {} });
+  assert.equal(invalid.statusCode, 400);
+
+  await app.close();
+});
+`
+
+const nestEntityTemplate = `export interface {{.Type}} {
+  id: number;
+{{- range .Fields}}
+  {{.JSON}}: {{.TSType}};
+{{- end}}
+}
+`
+
+const nestCreateDtoTemplate = `import { {{.Validators}} } from 'class-validator';
+
+export class Create{{.Type}}Dto {
+{{- range $i, $f := .Fields}}
+{{- if $i}}
+{{end}}
+  @{{$f.Validator}}()
+  {{$f.JSON}}: {{$f.TSType}};
+{{- end}}
+}
+`
+
+const nestUpdateDtoTemplate = `import { Create{{.Type}}Dto } from './create-{{.Kebab}}.dto';
+
+export class Update{{.Type}}Dto extends Create{{.Type}}Dto {}
+`
+
+const nestServiceTemplate = `import { Injectable, NotFoundException } from '@nestjs/common';
+import { Create{{.Type}}Dto } from './dto/create-{{.Kebab}}.dto';
+import { Update{{.Type}}Dto } from './dto/update-{{.Kebab}}.dto';
+import { {{.Type}} } from './entities/{{.Kebab}}.entity';
+
+@Injectable()
+export class {{.PluralType}}Service {
+  private readonly items = new Map<number, {{.Type}}>();
+  private nextId = 1;
+
+  findAll(): {{.Type}}[] {
+    return Array.from(this.items.values()).sort((a, b) => a.id - b.id);
+  }
+
+  findOne(id: number): {{.Type}} {
+    const {{.Var}} = this.items.get(id);
+    if (!{{.Var}}) {
+      throw new NotFoundException('{{.Label}} ' + id + ' not found');
+    }
+    return {{.Var}};
+  }
+
+  create(dto: Create{{.Type}}Dto): {{.Type}} {
+    const {{.Var}}: {{.Type}} = { ...dto, id: this.nextId++ };
+    this.items.set({{.Var}}.id, {{.Var}});
+    return {{.Var}};
+  }
+
+  update(id: number, dto: Update{{.Type}}Dto): {{.Type}} {
+    this.findOne(id);
+    const {{.Var}}: {{.Type}} = { ...dto, id };
+    this.items.set(id, {{.Var}});
+    return {{.Var}};
+  }
+
+  remove(id: number): void {
+    this.findOne(id);
+    this.items.delete(id);
+  }
+}
+`
+
+const nestControllerTemplate = `import { Body, Controller, Delete, Get, HttpCode, Param, ParseIntPipe, Post, Put } from '@nestjs/common';
+import { {{.PluralType}}Service } from './{{.PluralKebab}}.service';
+import { Create{{.Type}}Dto } from './dto/create-{{.Kebab}}.dto';
+import { Update{{.Type}}Dto } from './dto/update-{{.Kebab}}.dto';
+
+@Controller('{{.PluralKebab}}')
+export class {{.PluralType}}Controller {
+  constructor(private readonly {{.PluralVar}}Service: {{.PluralType}}Service) {}
+
+  @Get()
+  findAll() {
+    return this.{{.PluralVar}}Service.findAll();
+  }
+
+  @Get(':id')
+  findOne(@Param('id', ParseIntPipe) id: number) {
+    return this.{{.PluralVar}}Service.findOne(id);
+  }
+
+  @Post()
+  create(@Body() dto: Create{{.Type}}Dto) {
+    return this.{{.PluralVar}}Service.create(dto);
+  }
+
+  @Put(':id')
+  update(@Param('id', ParseIntPipe) id: number, @Body() dto: Update{{.Type}}Dto) {
+    return this.{{.PluralVar}}Service.update(id, dto);
+  }
+
+  @Delete(':id')
+  @HttpCode(204)
+  remove(@Param('id', ParseIntPipe) id: number) {
+    this.{{.PluralVar}}Service.remove(id);
+  }
+}
+`
+
+const nestModuleTemplate = `import { Module } from '@nestjs/common';
+import { {{.PluralType}}Controller } from './{{.PluralKebab}}.controller';
+import { {{.PluralType}}Service } from './{{.PluralKebab}}.service';
+
+@Module({
+  controllers: [{{.PluralType}}Controller],
+  providers: [{{.PluralType}}Service],
+})
+export class {{.PluralType}}Module {}
+`
+
+const nestServiceSpecTemplate = `import { NotFoundException } from '@nestjs/common';
+import { {{.PluralType}}Service } from './{{.PluralKebab}}.service';
+
+describe('{{.PluralType}}Service', () => {
+  let service: {{.PluralType}}Service;
+
+  beforeEach(() => {
+    service = new {{.PluralType}}Service();
+  });
+
+  it('creates and finds a {{.Label}}', () => {
+    const created = service.create({{.SampleJSON}});
+    expect(service.findOne(created.id)).toEqual(created);
+  });
+
+  it('throws when a {{.Label}} does not exist', () => {
+    expect(() => service.findOne(42)).toThrow(NotFoundException);
+  });
+});
+`
@@ -0,0 +1,272 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/moabdelazem/initiator/internal/docker"
+	"github.com/moabdelazem/initiator/internal/projects"
+)
+
+// Format is the task runner a task file is generated for
+type Format string
+
+const (
+	Make Format = "make"
+	Task Format = "task"
+)
+
+// ParseFormat validates a task file format
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case Make, Task:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported task file format %q, use %s or %s", name, Make, Task)
+	}
+}
+
+// Var is a variable defined at the top of the task file
+type Var struct {
+	Name  string
+	Value string
+	// Shell evaluates Value as a shell command
+	Shell bool
+}
+
+// Target is a task of the task file, commands use Make variable syntax like $(VERSION)
+type Target struct {
+	Name        string
+	Description string
+	Deps        []string
+	Commands    []string
+}
+
+// Generator generates a Makefile or Taskfile.yml for a project
+type Generator struct {
+	Project *projects.ProjectInfo
+	Format  Format
+	AppName string
+	// Force overwrites an existing task file
+	Force bool
+}
+
+// NewGenerator creates a new Generator for the project. The app name matches the
+// Docker image name generated by the docker package.
+func NewGenerator(project *projects.ProjectInfo, format Format, force bool) *Generator {
+	return &Generator{
+		Project: project,
+		Format:  format,
+		AppName: docker.DefaultImageName(project.Dir),
+		Force:   force,
+	}
+}
+
+// FileName returns the name of the generated task file
+func (g *Generator) FileName() string {
+	if g.Format == Task {
+		return "Taskfile.yml"
+	}
+	return "Makefile"
+}
+
+// Generate writes the task file into the project directory.
+// It refuses to overwrite an existing one unless Force is set.
+func (g *Generator) Generate() error {
+	target := filepath.Join(g.Project.Dir, g.FileName())
+	if !g.Force {
+		if _, err := os.Stat(target); err == nil {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", g.FileName())
+		}
+	}
+
+	content, err := g.Render()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create %s: %v", g.FileName(), err)
+	}
+	return nil
+}
+
+// Render returns the content of the task file
+func (g *Generator) Render() (string, error) {
+	data := struct {
+		Vars    []Var
+		Targets []Target
+	}{g.Vars(), g.Targets()}
+
+	var tmpl *template.Template
+	var err error
+	if g.Format == Task {
+		tmpl, err = template.New("taskfile").Delims("[[", "]]").Funcs(template.FuncMap{
+			"quote":    yamlString,
+			"taskVars": taskVars,
+			"list":     yamlList,
+		}).Parse(taskfileTemplate)
+	} else {
+		tmpl, err = template.New("makefile").Parse(makefileTemplate)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", g.FileName(), err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %v", g.FileName(), err)
+	}
+	return buf.String(), nil
+}
+
+// Vars returns the variables shared by the targets
+func (g *Generator) Vars() []Var {
+	vars := []Var{
+		{Name: "APP_NAME", Value: g.AppName},
+		{Name: "VERSION", Value: "git describe --tags --always --dirty 2>/dev/null || echo dev", Shell: true},
+	}
+	if g.Project.Type == projects.GoLang {
+		vars = append(vars, Var{Name: "LDFLAGS", Value: `-ldflags "-s -w -X main.version=$(VERSION)"`})
+	}
+	if g.exists(filepath.Join("k8s", "overlays")) {
+		// Select the overlay with: make k8s-apply ENVIRONMENT=prod
+		vars = append(vars, Var{Name: "ENVIRONMENT", Value: "dev"})
+	}
+	return vars
+}
+
+// Targets returns the targets matching the project variant and the artifacts
+// generated next to it: Dockerfile, compose.yaml and the k8s/ manifests, applied
+// through the overlay of $(ENVIRONMENT) when they use the Kustomize layout.
+func (g *Generator) Targets() []Target {
+	var targets []Target
+	if g.Project.Type == projects.GoLang {
+		targets = g.goTargets()
+	} else {
+		targets = g.nodeTargets()
+	}
+
+	if g.exists("Dockerfile") {
+		run := "docker run --rm"
+		if port := docker.DefaultPort(g.Project); port != 0 {
+			run += fmt.Sprintf(" -p %d:%d", port, port)
+		}
+		if g.exists(".env") {
+			run += " --env-file .env"
+		}
+		targets = append(targets,
+			Target{Name: "docker-build", Description: "Build the Docker image", Commands: []string{"docker build -t $(APP_NAME):$(VERSION) ."}},
+			Target{Name: "docker-run", Description: "Run the Docker image", Deps: []string{"docker-build"}, Commands: []string{run + " $(APP_NAME):$(VERSION)"}},
+		)
+	}
+
+	if g.exists("compose.yaml") {
+		targets = append(targets,
+			Target{Name: "up", Description: "Start the Docker Compose stack", Commands: []string{"docker compose up --build"}},
+			Target{Name: "down", Description: "Stop the Docker Compose stack", Commands: []string{"docker compose down"}},
+		)
+	}
+
+	if g.exists(filepath.Join("k8s", "overlays")) {
+		targets = append(targets,
+			Target{Name: "k8s-apply", Description: "Apply the Kustomize overlay selected by ENVIRONMENT", Commands: []string{"kubectl apply -k k8s/overlays/$(ENVIRONMENT)"}},
+			Target{Name: "k8s-delete", Description: "Delete the resources of the overlay selected by ENVIRONMENT", Commands: []string{"kubectl delete -k k8s/overlays/$(ENVIRONMENT)"}},
+		)
+	} else if g.exists("k8s") {
+		targets = append(targets,
+			Target{Name: "k8s-apply", Description: "Apply the Kubernetes manifests", Commands: []string{"kubectl apply -f k8s/"}},
+			Target{Name: "k8s-delete", Description: "Delete the Kubernetes resources", Commands: []string{"kubectl delete -f k8s/"}},
+		)
+	}
+
+	return targets
+}
+
+// goTargets returns the build, run, test, lint and clean targets of Go projects
+func (g *Generator) goTargets() []Target {
+	lint := []string{"go vet ./..."}
+	if g.exists(".golangci.yml") {
+		lint = append(lint, "golangci-lint run")
+	}
+
+	return []Target{
+		{Name: "build", Description: "Build the binary", Commands: []string{"go build $(LDFLAGS) -o bin/$(APP_NAME) ./cmd"}},
+		{Name: "run", Description: "Run the application", Commands: []string{"go run ./cmd"}},
+		{Name: "test", Description: "Run the tests", Commands: []string{"go test ./..."}},
+		{Name: "lint", Description: "Run the linters", Commands: lint},
+		{Name: "clean", Description: "Remove build artifacts", Commands: []string{"rm -rf bin/"}},
+	}
+}
+
+// nodeTargets returns the targets of Node.js projects, one per package.json script
+func (g *Generator) nodeTargets() []Target {
+	pm := g.Project.PackageManager.OrDefault()
+
+	targets := []Target{
+		{Name: "install", Description: "Install the dependencies", Commands: []string{strings.Join(pm.Install().Args, " ")}},
+	}
+
+	scripts := []struct {
+		name        string
+		description string
+	}{
+		{"dev", "Run the development server"},
+		{"start", "Run the application"},
+		{"build", "Build the application"},
+		{"test", "Run the tests"},
+		{"lint", "Run the linters"},
+	}
+	for _, script := range scripts {
+		if g.Project.HasScript(script.name) {
+			targets = append(targets, Target{Name: script.name, Description: script.description, Commands: []string{pm.Run(script.name)}})
+		}
+	}
+
+	output := "dist/"
+	switch g.Project.NodeType {
+	case projects.NextJS:
+		output = ".next/"
+	case projects.Remix:
+		output = "build/"
+	}
+	return append(targets, Target{Name: "clean", Description: "Remove build artifacts", Commands: []string{"rm -rf " + output}})
+}
+
+// exists reports whether the file or directory exists in the project
+func (g *Generator) exists(name string) bool {
+	_, err := os.Stat(filepath.Join(g.Project.Dir, name))
+	return err == nil
+}
+
+// makeVar matches a Make variable reference like $(VERSION)
+var makeVar = regexp.MustCompile(`\$\(([A-Z_]+)\)`)
+
+// taskVars converts Make variable references to Task template variables
+func taskVars(command string) string {
+	return makeVar.ReplaceAllString(command, "{{.$1}}")
+}
+
+// yamlString quotes a value when it would not be read back as the same YAML string
+func yamlString(value string) string {
+	plain := value != "" &&
+		!strings.ContainsAny(value[:1], "{}[]&*!|>'\"%@`#,?:-") &&
+		!strings.Contains(value, ": ") &&
+		!strings.Contains(value, " #") &&
+		!strings.HasSuffix(value, ":")
+	if plain {
+		return value
+	}
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
+
+// yamlList renders a flow sequence of plain values
+func yamlList(values []string) string {
+	return "[" + strings.Join(values, ", ") + "]"
+}
@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moabdelazem/initiator/internal/projects"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerate_GoMakefile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	if err := os.MkdirAll(filepath.Join(dir, "k8s"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	generator := NewGenerator(project, Make, false)
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "Makefile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, snippet := range []string{
+		".DEFAULT_GOAL := help",
+		`LDFLAGS := -ldflags "-s -w -X main.version=$(VERSION)"`,
+		"build: ## Build the binary\n\tgo build $(LDFLAGS) -o bin/$(APP_NAME) ./cmd",
+		"k8s-apply: ## Apply the Kubernetes manifests",
+	} {
+		if !strings.Contains(string(content), snippet) {
+			t.Errorf("expected Makefile to contain %q, got:\n%s", snippet, content)
+		}
+	}
+	if strings.Contains(string(content), "docker-build") {
+		t.Errorf("expected no docker targets without a Dockerfile, got:\n%s", content)
+	}
+
+	if err := generator.Generate(); err == nil {
+		t.Fatal("expected an error when the Makefile already exists")
+	}
+}
+
+func TestRender_Taskfile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "k8s", "overlays", "dev"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &projects.ProjectInfo{Dir: dir, Name: "example.com/api", Type: projects.GoLang, GoType: projects.WebGo}
+	content, err := NewGenerator(project, Task, false).Render()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var taskfile struct {
+		Vars  map[string]interface{} `yaml:"vars"`
+		Tasks map[string]struct {
+			Deps []string `yaml:"deps"`
+			Cmds []string `yaml:"cmds"`
+		} `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &taskfile); err != nil {
+		t.Fatalf("expected valid YAML, got %v:\n%s", err, content)
+	}
+
+	if taskfile.Vars["LDFLAGS"] != `-ldflags "-s -w -X main.version={{.VERSION}}"` {
+		t.Errorf("unexpected LDFLAGS: %v", taskfile.Vars["LDFLAGS"])
+	}
+	if cmds := taskfile.Tasks["build"].Cmds; len(cmds) != 1 || cmds[0] != "go build {{.LDFLAGS}} -o bin/{{.APP_NAME}} ./cmd" {
+		t.Errorf("unexpected build commands: %v", cmds)
+	}
+	if deps := taskfile.Tasks["docker-run"].Deps; len(deps) != 1 || deps[0] != "docker-build" {
+		t.Errorf("unexpected docker-run deps: %v", deps)
+	}
+	if taskfile.Vars["ENVIRONMENT"] != "dev" {
+		t.Errorf("expected the dev overlay by default, got %v", taskfile.Vars["ENVIRONMENT"])
+	}
+	if cmds := taskfile.Tasks["k8s-apply"].Cmds; len(cmds) != 1 || cmds[0] != "kubectl apply -k k8s/overlays/{{.ENVIRONMENT}}" {
+		t.Errorf("unexpected k8s-apply commands: %v", cmds)
+	}
+	if _, ok := taskfile.Tasks["help"]; !ok {
+		t.Error("expected a help task")
+	}
+}
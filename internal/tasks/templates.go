@@ -0,0 +1,56 @@
+package tasks
+
+const makefileTemplate = `.DEFAULT_GOAL := help
+
+{{range .Vars -}}
+{{.Name}} := {{if .Shell}}$(shell {{.Value}}){{else}}{{.Value}}{{end}}
+{{end}}
+.PHONY: help{{range .Targets}} {{.Name}}{{end}}
+
+help: ## Show this help
+	@awk 'BEGIN {FS = ":.*## "} /^[a-zA-Z0-9_-]+:.*## / {printf "  \033[36m%-14s\033[0m %s\n", $$1, $$2}' $(MAKEFILE_LIST)
+{{- range .Targets}}
+
+{{.Name}}:{{range .Deps}} {{.}}{{end}} ## {{.Description}}
+{{- range .Commands}}
+	{{.}}
+{{- end}}
+{{- end}}
+`
+
+// taskfileTemplate is rendered with [[ ]] delimiters because Task itself uses {{ }}
+const taskfileTemplate = `version: '3'
+
+vars:
+[[- range .Vars]]
+  [[.Name]]:
+[[- if .Shell]]
+    sh: [[quote .Value]]
+[[- else]] [[quote (taskVars .Value)]]
+[[- end]]
+[[- end]]
+
+tasks:
+  default:
+    cmds:
+      - task --list
+    silent: true
+
+  help:
+    desc: Show this help
+    cmds:
+      - task --list
+    silent: true
+[[- range .Targets]]
+
+  [[.Name]]:
+    desc: [[quote .Description]]
+[[- if .Deps]]
+    deps: [[list .Deps]]
+[[- end]]
+    cmds:
+[[- range .Commands]]
+      - [[quote (taskVars .)]]
+[[- end]]
+[[- end]]
+`
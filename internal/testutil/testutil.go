@@ -0,0 +1,24 @@
+// Package testutil holds assertions shared by the generator tests.
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ExpectContains fails the test if the file does not contain every snippet.
+func ExpectContains(t testing.TB, path string, snippets ...string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	for _, snippet := range snippets {
+		if !strings.Contains(string(content), snippet) {
+			t.Errorf("expected %s to contain %q, got:\n%s", filepath.Base(path), snippet, content)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// InsertBeforeLine inserts text before the first line of the file that contains anchor.
+// The inserted lines take over the indentation of the anchor line. If the file already
+// contains the text the file is left untouched, so repeated runs do not duplicate code.
+//
+// Parameters:
+//   - path: The file to edit
+//   - anchor: A substring identifying the line to insert before
+//   - text: The text to insert, may span multiple lines
+//
+// Returns:
+//   - bool: false if no line contains the anchor, true otherwise
+//   - error: An error if the file cannot be read or written
+func InsertBeforeLine(path string, anchor string, text string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	if strings.Contains(string(content), strings.TrimSpace(text)) {
+		return true, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, anchor) {
+			continue
+		}
+
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		var inserted []string
+		for _, l := range strings.Split(text, "\n") {
+			if l == "" {
+				inserted = append(inserted, l)
+				continue
+			}
+			inserted = append(inserted, indent+l)
+		}
+
+		result := append([]string{}, lines[:i]...)
+		result = append(result, inserted...)
+		result = append(result, lines[i:]...)
+		return true, WriteLines(path, result)
+	}
+
+	return false, nil
+}
+
+// InsertAfterLastLine inserts text after the last line of the file that starts with prefix.
+// It is typically used to add an import statement after the existing ones. When no line
+// starts with prefix the text is placed at the top of the file. Text that is already
+// present in the file is not inserted again.
+//
+// Parameters:
+//   - path: The file to edit
+//   - prefix: The line prefix to look for, e.g. "import "
+//   - text: The text to insert
+//
+// Returns:
+//   - error: An error if the file cannot be read or written
+func InsertAfterLastLine(path string, prefix string, text string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	if strings.Contains(string(content), strings.TrimSpace(text)) {
+		return nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	position := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			position = i + 1
+		}
+	}
+
+	result := append([]string{}, lines[:position]...)
+	result = append(result, strings.Split(text, "\n")...)
+	result = append(result, lines[position:]...)
+	return WriteLines(path, result)
+}
+
+// WriteLines joins the lines with newlines and writes them to path.
+func WriteLines(path string, lines []string) error {
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
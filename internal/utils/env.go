@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvVar is a single KEY=value entry of a .env file
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// ReadEnvFile parses a .env file and returns its entries in file order.
+// Blank lines and comments are skipped and surrounding quotes are removed from values.
+// A missing file yields no entries and no error.
+func ReadEnvFile(path string) ([]EnvVar, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var vars []EnvVar
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars = append(vars, EnvVar{Key: strings.TrimSpace(key), Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	return vars, nil
+}
+
+// AppendEnvFile appends the entries whose key is not yet defined in the .env file,
+// preceded by a comment header. The file is created when it does not exist.
+// It returns the keys that were added.
+func AppendEnvFile(path string, header string, vars []EnvVar) ([]string, error) {
+	existing, err := ReadEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defined := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		defined[v.Key] = true
+	}
+
+	var added []string
+	var b strings.Builder
+	for _, v := range vars {
+		if defined[v.Key] {
+			continue
+		}
+		defined[v.Key] = true
+		added = append(added, v.Key)
+		fmt.Fprintf(&b, "%s=%s\n", v.Key, v.Value)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	var out strings.Builder
+	out.Write(content)
+	if len(content) > 0 {
+		if !strings.HasSuffix(string(content), "\n") {
+			out.WriteString("\n")
+		}
+		out.WriteString("\n")
+	}
+	if header != "" {
+		fmt.Fprintf(&out, "# %s\n", header)
+	}
+	out.WriteString(b.String())
+
+	if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %v", path, err)
+	}
+	return added, nil
+}
@@ -231,6 +231,8 @@ func promptUserForGit() bool {
 	}
 }
 
+// initializeGitRepository runs 'git init' in the project directory. The .gitignore
+// file is written once the project type is known, see the gitignore package.
 func initializeGitRepository(path string) error {
 	green := color.New(color.FgGreen).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
@@ -247,40 +249,5 @@ func initializeGitRepository(path string) error {
 	s.Stop()
 	fmt.Printf("%s Git repository initialized\n", green("✓"))
 
-	// Create .gitignore file
-	s = CreateSpinner("Creating .gitignore file...")
-	s.Start()
-	gitignore := `# Dependencies
-node_modules/
-npm-debug.log*
-yarn-debug.log*
-yarn-error.log*
-
-# Production build
-dist/
-build/
-
-# Environment variables
-.env
-.env.local
-.env.*.local
-
-# IDE/Editor specific
-.idea/
-.vscode/
-*.swp
-*.swo
-
-# OS specific
-.DS_Store
-Thumbs.db`
-
-	if err := os.WriteFile(filepath.Join(path, ".gitignore"), []byte(gitignore), 0644); err != nil {
-		s.Stop()
-		return fmt.Errorf("%s Failed to create .gitignore: %v", red("✘"), err)
-	}
-	s.Stop()
-	fmt.Printf("%s Created .gitignore file\n", green("✓"))
-
 	return nil
 }
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// commitMsgHook is the commit-msg hook enforcing Conventional Commits.
+const commitMsgHook = `#!/bin/sh
+# Installed by initiator: commit messages must follow Conventional Commits.
+# https://www.conventionalcommits.org
+
+first_line=$(head -n 1 "$1")
+
+case "$first_line" in
+  Merge* | Revert* | fixup!* | squash!* | amend!*) exit 0 ;;
+esac
+
+pattern='^(build|chore|ci|docs|feat|fix|perf|refactor|revert|style|test)(\([a-zA-Z0-9._/-]+\))?!?: .+'
+
+if ! printf '%s\n' "$first_line" | grep -Eq "$pattern"; then
+  echo "commit-msg: \"$first_line\" does not follow Conventional Commits" >&2
+  echo "expected <type>(<scope>): <description>, e.g. \"feat(api): add users endpoint\"" >&2
+  echo "types: build, chore, ci, docs, feat, fix, perf, refactor, revert, style, test" >&2
+  exit 1
+fi
+`
+
+// GitOptions configures a repository created for a new project
+type GitOptions struct {
+	// Branch is the name of the default branch, e.g. "main"
+	Branch string
+	// Remote is the URL added as the "origin" remote
+	Remote string
+	// Author overrides the commit author, in the "Name <email>" form
+	Author string
+	// CommitMsgHook installs a commit-msg hook enforcing Conventional Commits
+	CommitMsgHook bool
+}
+
+// ConfigureGitRepo applies the default branch, the origin remote and the commit-msg
+// hook to the repository in dir. It must run before the first commit so the
+// default branch can be renamed.
+//
+// Parameters:
+//   - dir: The root directory of the Git repository
+//   - opts: The options to apply, empty fields are skipped
+//
+// Returns:
+//   - error: An error if a git command fails or the hook cannot be written
+func ConfigureGitRepo(dir string, opts GitOptions) error {
+	if opts.Branch != "" {
+		if err := runGit(dir, nil, "symbolic-ref", "HEAD", "refs/heads/"+opts.Branch); err != nil {
+			return err
+		}
+	}
+
+	if opts.Remote != "" {
+		if err := runGit(dir, nil, "remote", "add", "origin", opts.Remote); err != nil {
+			return err
+		}
+	}
+
+	if opts.CommitMsgHook {
+		hookPath := filepath.Join(dir, ".git", "hooks", "commit-msg")
+		if err := os.MkdirAll(filepath.Dir(hookPath), 0755); err != nil {
+			return fmt.Errorf("failed to create hooks directory: %v", err)
+		}
+		if err := os.WriteFile(hookPath, []byte(commitMsgHook), 0755); err != nil {
+			return fmt.Errorf("failed to install commit-msg hook: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// CommitAll stages every file in dir and records a commit with the given message.
+// The author is taken from opts.Author when set, otherwise from the git configuration.
+//
+// Returns:
+//   - error: An error if no author is configured or a git command fails
+func CommitAll(dir string, message string, opts GitOptions) error {
+	var identity []string
+	if opts.Author != "" {
+		name, email, err := ParseAuthor(opts.Author)
+		if err != nil {
+			return err
+		}
+		identity = []string{
+			"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+			"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email,
+		}
+	} else if GitConfig(dir, "user.name") == "" || GitConfig(dir, "user.email") == "" {
+		return fmt.Errorf("no commit author configured, pass --author \"Name <email>\" or run git config --global user.name and user.email")
+	}
+
+	if err := runGit(dir, nil, "add", "-A"); err != nil {
+		return err
+	}
+	return runGit(dir, identity, "commit", "-q", "-m", message)
+}
+
+// ParseAuthor splits an author in the "Name <email>" form
+func ParseAuthor(author string) (string, string, error) {
+	address, err := mail.ParseAddress(author)
+	if err != nil || address.Name == "" {
+		return "", "", fmt.Errorf("invalid author %q, expected \"Name <email>\"", author)
+	}
+	return address.Name, address.Address, nil
+}
+
+// GitConfig returns a git configuration value, or an empty string when it is unset
+func GitConfig(dir string, key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// CurrentBranch returns the branch checked out in the repository at dir, also
+// before its first commit, or an empty string when dir is not a repository
+func CurrentBranch(dir string) string {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// runGit runs a git command in dir with extra environment variables and includes
+// its output in the returned error
+func runGit(dir string, env []string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(cmd.Environ(), env...)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %v - %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepo creates an empty Git repository in a temporary directory
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	if err := runGit(dir, nil, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// gitOutput runs a git command in dir and returns its trimmed output
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s failed: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestConfigureGitRepo(t *testing.T) {
+	dir := initRepo(t)
+
+	opts := GitOptions{Branch: "trunk", Remote: "git@github.com:acme/shop.git", CommitMsgHook: true}
+	if err := ConfigureGitRepo(dir, opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if branch := gitOutput(t, dir, "symbolic-ref", "--short", "HEAD"); branch != "trunk" {
+		t.Errorf("expected default branch trunk, got %s", branch)
+	}
+	if remote := gitOutput(t, dir, "remote", "get-url", "origin"); remote != opts.Remote {
+		t.Errorf("expected origin %s, got %s", opts.Remote, remote)
+	}
+}
+
+func TestCommitAll_EnforcesConventionalCommits(t *testing.T) {
+	dir := initRepo(t)
+	opts := GitOptions{Branch: "main", Author: "Jane Doe <jane@example.com>", CommitMsgHook: true}
+	if err := ConfigureGitRepo(dir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# shop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CommitAll(dir, "initial commit", opts); err == nil {
+		t.Error("expected the commit-msg hook to reject a non-conventional message")
+	}
+
+	if err := CommitAll(dir, "chore: initial commit", opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if author := gitOutput(t, dir, "log", "-1", "--format=%an <%ae>"); author != opts.Author {
+		t.Errorf("expected author %s, got %s", opts.Author, author)
+	}
+	if branch := gitOutput(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("expected the commit on main, got %s", branch)
+	}
+}
+
+func TestParseAuthor(t *testing.T) {
+	name, email, err := ParseAuthor("Jane Doe <jane@example.com>")
+	if err != nil || name != "Jane Doe" || email != "jane@example.com" {
+		t.Errorf("unexpected result: %q %q %v", name, email, err)
+	}
+
+	for _, author := range []string{"jane@example.com", "Jane Doe", ""} {
+		if _, _, err := ParseAuthor(author); err == nil {
+			t.Errorf("expected an error for %q", author)
+		}
+	}
+}
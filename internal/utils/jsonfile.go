@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SetJSONField sets a top-level field of a JSON object file such as package.json.
+// Unlike decoding into a map and encoding it again, the file keeps its key order and
+// formatting: an existing field has its value replaced in place and a new field is
+// appended after the last one, using the indentation of the first.
+//
+// Parameters:
+//   - path: The JSON file to edit, its root must be an object
+//   - key: The top-level key to set
+//   - value: The value, encoded with encoding/json
+//
+// Returns:
+//   - error: An error if the file cannot be read, parsed or written
+func SetJSONField(path string, key string, value interface{}) error {
+	return SetJSONPath(path, []string{key}, value)
+}
+
+// SetJSONPath sets a nested field of a JSON object file, e.g. []string{"scripts", "lint"}
+// sets the lint script of package.json. Missing parent objects are created. Like
+// SetJSONField, the rest of the file keeps its key order and formatting.
+//
+// Returns:
+//   - error: An error if the file cannot be read, parsed or written, or a parent is not an object
+func SetJSONPath(path string, keys []string, value interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %v", strings.Join(keys, "."), err)
+	}
+
+	updated, err := setField(content, keys, encoded, "")
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %v", path, err)
+	}
+	if !json.Valid(updated) {
+		return fmt.Errorf("failed to update %s: the result is not valid JSON", path)
+	}
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// setField sets the field at keys in the JSON object held by content, whose closing
+// brace is indented with outer, and returns the updated content.
+func setField(content []byte, keys []string, encoded []byte, outer string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	objectStart := dec.InputOffset()
+
+	indent := outer + "  "
+	rest := string(content[objectStart:])
+	if quote := strings.IndexByte(rest, '"'); quote != -1 {
+		if ws := rest[:quote]; strings.TrimSpace(ws) == "" && strings.Contains(ws, "\n") {
+			indent = ws[strings.LastIndexByte(ws, '\n')+1:]
+		}
+	}
+
+	end := objectStart
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keyEnd := dec.InputOffset()
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		end = dec.InputOffset()
+
+		if token != keys[0] {
+			continue
+		}
+
+		// The value starts after the colon and any whitespace around it
+		valueStart := keyEnd + int64(bytes.IndexByte(content[keyEnd:], ':')) + 1
+		for strings.ContainsRune(" \t\r\n", rune(content[valueStart])) {
+			valueStart++
+		}
+
+		value := encoded
+		if len(keys) > 1 {
+			if value, err = setField(content[valueStart:end], keys[1:], encoded, indent); err != nil {
+				return nil, fmt.Errorf("%s: %v", keys[0], err)
+			}
+		}
+		return join(content[:valueStart], value, content[end:]), nil
+	}
+
+	// Missing parents become nested objects holding the field
+	value := encoded
+	for i := len(keys) - 1; i > 0; i-- {
+		name, _ := json.Marshal(keys[i])
+		value = []byte(fmt.Sprintf("{%s: %s}", name, value))
+	}
+	name, _ := json.Marshal(keys[0])
+	field := []byte(fmt.Sprintf("%s: %s", name, value))
+
+	if end == objectStart {
+		// Empty object, the closing brace follows the new field
+		closing := objectStart + int64(bytes.IndexByte(content[objectStart:], '}'))
+		return join(content[:objectStart], []byte("\n"+indent), field, []byte("\n"+outer), content[closing:]), nil
+	}
+	return join(content[:end], []byte(",\n"+indent), field, content[end:]), nil
+}
+
+// join concatenates the parts into a new slice
+func join(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
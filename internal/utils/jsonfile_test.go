@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetJSONField(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "replaces an existing field in place",
+			content: "{\n  \"name\": \"shop\",\n  \"license\": \"ISC\",\n  \"scripts\": {\n    \"license\": \"x\"\n  }\n}\n",
+			want:    "{\n  \"name\": \"shop\",\n  \"license\": \"MIT\",\n  \"scripts\": {\n    \"license\": \"x\"\n  }\n}\n",
+		},
+		{
+			name:    "appends a missing field with the file indentation",
+			content: "{\n    \"name\": \"shop\",\n    \"private\": true\n}\n",
+			want:    "{\n    \"name\": \"shop\",\n    \"private\": true,\n    \"license\": \"MIT\"\n}\n",
+		},
+		{
+			name:    "fills an empty object",
+			content: "{}\n",
+			want:    "{\n  \"license\": \"MIT\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "package.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := SetJSONField(path, "license", "MIT"); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, content)
+			}
+		})
+	}
+}
+
+func TestSetJSONField_RejectsNonObjects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "package.json")
+	if err := os.WriteFile(path, []byte("[1, 2]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetJSONField(path, "license", "MIT"); err == nil {
+		t.Error("expected an error for a JSON array")
+	}
+}
+
+func TestSetJSONPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "adds a nested field after the last one",
+			content: "{\n  \"name\": \"shop\",\n  \"scripts\": {\n    \"dev\": \"vite\"\n  }\n}\n",
+			want:    "{\n  \"name\": \"shop\",\n  \"scripts\": {\n    \"dev\": \"vite\",\n    \"lint\": \"eslint .\"\n  }\n}\n",
+		},
+		{
+			name:    "fills an empty nested object",
+			content: "{\n  \"scripts\": {}\n}\n",
+			want:    "{\n  \"scripts\": {\n    \"lint\": \"eslint .\"\n  }\n}\n",
+		},
+		{
+			name:    "creates missing parents",
+			content: "{\n  \"name\": \"shop\"\n}\n",
+			want:    "{\n  \"name\": \"shop\",\n  \"scripts\": {\"lint\": \"eslint .\"}\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "package.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := SetJSONPath(path, []string{"scripts", "lint"}, "eslint ."); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, content)
+			}
+		})
+	}
+}
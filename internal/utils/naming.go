@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// splitWords splits identifiers such as blog_post, blog-post, blogPost or BlogPost into
+// their lower case words.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		// Start a new word at a lower-to-upper transition, e.g. blog|Post,
+		// and before the last capital of an acronym, e.g. HTTP|Status
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+// PascalCase converts an identifier such as blog-post or blog_post to BlogPost.
+func PascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// CamelCase converts an identifier such as blog-post or blog_post to blogPost.
+func CamelCase(s string) string {
+	pascal := PascalCase(s)
+	if pascal == "" {
+		return ""
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+// SnakeCase converts an identifier such as BlogPost or blog-post to blog_post.
+func SnakeCase(s string) string {
+	return strings.Join(splitWords(s), "_")
+}
+
+// KebabCase converts an identifier such as BlogPost or blog_post to blog-post.
+func KebabCase(s string) string {
+	return strings.Join(splitWords(s), "-")
+}
+
+// Plural returns the English plural of a word using the common suffix rules,
+// e.g. post -> posts, category -> categories, address -> addresses.
+func Plural(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "y") && len(s) > 1 && !strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	}
+	return s + "s"
+}
+
+// GoFieldName converts a JSON property name into an exported Go identifier,
+// following the Go convention for common initialisms such as ID and URL.
+func GoFieldName(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		switch word {
+		case "id", "url", "api", "http", "json", "uuid", "sql", "ip":
+			b.WriteString(strings.ToUpper(word))
+		default:
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "Field" + name
+	}
+	return name
+}
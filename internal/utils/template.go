@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// RenderFile renders a template into a file relative to dir, creating its parent
+// directories. Go sources are formatted with gofmt before they are written.
+//
+// Parameters:
+//   - dir: The project directory relPath is relative to.
+//   - relPath: The path of the file to write, also naming the template in errors.
+//   - content: The template to render.
+//   - funcs: Extra template functions, nil when the template needs none.
+//   - data: The value the template is executed with.
+//
+// Returns:
+//   - error: An error if the template fails to render or the file cannot be written.
+func RenderFile(dir string, relPath string, content string, funcs template.FuncMap, data interface{}) error {
+	rendered, err := RenderTemplate(relPath, content, funcs, data)
+	if err != nil {
+		return err
+	}
+	return WriteFile(dir, relPath, rendered)
+}
+
+// RenderTemplate renders the template of the file at relPath in memory, formatting
+// Go sources with gofmt, so a generator can render every file before writing any.
+func RenderTemplate(relPath string, content string, funcs template.FuncMap, data interface{}) ([]byte, error) {
+	tmpl, err := template.New(filepath.Base(relPath)).Funcs(funcs).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %v", err)
+	}
+
+	rendered := buf.Bytes()
+	if strings.HasSuffix(relPath, ".go") {
+		formatted, err := format.Source(rendered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %v", relPath, err)
+		}
+		rendered = formatted
+	}
+	return rendered, nil
+}
+
+// WriteFile writes the content into a file relative to dir, creating its parent
+// directories.
+func WriteFile(dir string, relPath string, content []byte) error {
+	path := filepath.Join(dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %v", filepath.Dir(relPath), err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to create %s: %v", relPath, err)
+	}
+	return nil
+}